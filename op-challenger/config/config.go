@@ -110,6 +110,7 @@ type Config struct {
 	L1Beacon             string           // L1 Beacon API Url
 	GameFactoryAddress   common.Address   // Address of the dispute game factory
 	GameAllowlist        []common.Address // Allowlist of fault game addresses
+	GameDenylist         []common.Address // Denylist of fault game addresses, takes precedence over the allowlist
 	GameWindow           time.Duration    // Maximum time duration to look for games to progress
 	Datadir              string           // Data Directory
 	MaxConcurrency       uint             // Maximum number of threads to use when progressing games
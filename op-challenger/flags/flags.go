@@ -55,6 +55,12 @@ var (
 			"If empty, the challenger will play all games.",
 		EnvVars: prefixEnvVars("GAME_ALLOWLIST"),
 	}
+	GameDenylistFlag = &cli.StringSliceFlag{
+		Name: "game-denylist",
+		Usage: "List of Fault Game contract addresses the challenger must not play. " +
+			"Takes precedence over the game allowlist.",
+		EnvVars: prefixEnvVars("GAME_DENYLIST"),
+	}
 	TraceTypeFlag = &cli.StringSliceFlag{
 		Name:    "trace-type",
 		Usage:   "The trace types to support. Valid options: " + openum.EnumString(config.TraceTypes),
@@ -240,6 +246,7 @@ var optionalFlags = []cli.Flag{
 	HTTPPollInterval,
 	AdditionalBondClaimants,
 	GameAllowlistFlag,
+	GameDenylistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
 	CannonL2GenesisFlag,
@@ -406,6 +413,16 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			allowedGames = append(allowedGames, gameAddress)
 		}
 	}
+	var deniedGames []common.Address
+	if ctx.StringSlice(GameDenylistFlag.Name) != nil {
+		for _, addr := range ctx.StringSlice(GameDenylistFlag.Name) {
+			gameAddress, err := opservice.ParseAddress(addr)
+			if err != nil {
+				return nil, err
+			}
+			deniedGames = append(deniedGames, gameAddress)
+		}
+	}
 
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
@@ -452,6 +469,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		TraceTypes:                      traceTypes,
 		GameFactoryAddress:              gameFactoryAddress,
 		GameAllowlist:                   allowedGames,
+		GameDenylist:                    deniedGames,
 		GameWindow:                      ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:                  maxConcurrency,
 		L2Rpc:                           l2Rpc,
@@ -39,6 +39,7 @@ type L1HeaderSource interface {
 type TxSender interface {
 	From() common.Address
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitReceipt(txPurpose string, tx txmgr.TxCandidate) (*gethTypes.Receipt, error)
 }
 
 type GamePlayer struct {
@@ -141,7 +142,7 @@ func NewGamePlayer(
 	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
 	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
-	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
+	responder, err := responder.NewFaultResponder(logger, m, txSender, loader, uploader, oracle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
@@ -9,8 +9,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -32,11 +34,13 @@ type Oracle interface {
 
 type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitReceipt(txPurpose string, tx txmgr.TxCandidate) (*ethtypes.Receipt, error)
 }
 
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log      log.Logger
+	metrics  metrics.MoveMetricer
 	sender   TxSender
 	contract GameContract
 	uploader preimages.PreimageUploader
@@ -44,9 +48,10 @@ type FaultResponder struct {
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, sender TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
+func NewFaultResponder(logger log.Logger, m metrics.MoveMetricer, sender TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
+		metrics:  m,
 		sender:   sender,
 		contract: contract,
 		uploader: uploader,
@@ -90,6 +95,14 @@ func (r *FaultResponder) ResolveClaims(claimIdxs ...uint64) error {
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
+	moveType := metrics.MoveTypeStep
+	if action.Type == types.ActionTypeMove {
+		moveType = metrics.MoveTypeDefend
+		if action.IsAttack {
+			moveType = metrics.MoveTypeAttack
+		}
+	}
+	moveDone := r.metrics.RecordChallengerMove(moveType)
 	if action.OracleData != nil {
 		var preimageExists bool
 		var err error
@@ -134,5 +147,14 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 	if err != nil {
 		return err
 	}
-	return r.sender.SendAndWaitSimple("perform action", candidate)
+	rcpt, err := r.sender.SendAndWaitReceipt("perform action", candidate)
+	if rcpt != nil {
+		// Reverted moves still cost gas
+		r.metrics.RecordChallengerGasUsed(moveType, rcpt.GasUsed)
+	}
+	if err != nil {
+		return err
+	}
+	moveDone()
+	return nil
 }
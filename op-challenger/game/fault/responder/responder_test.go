@@ -8,10 +8,12 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/stretchr/testify/require"
@@ -24,6 +26,8 @@ var (
 	mockOracleExistsError = errors.New("mock oracle exists error")
 )
 
+const mockMoveGasUsed = 85_000
+
 // TestCallResolve tests the [Responder.CallResolve].
 func TestCallResolve(t *testing.T) {
 	t.Run("SendFails", func(t *testing.T) {
@@ -286,13 +290,47 @@ func TestPerformAction(t *testing.T) {
 	})
 }
 
+func TestPerformActionRecordsMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   types.Action
+		expected string
+	}{
+		{name: "attack", action: types.Action{Type: types.ActionTypeMove, IsAttack: true}, expected: metrics.MoveTypeAttack},
+		{name: "defend", action: types.Action{Type: types.ActionTypeMove, IsAttack: false}, expected: metrics.MoveTypeDefend},
+		{name: "step", action: types.Action{Type: types.ActionTypeStep, IsAttack: true}, expected: metrics.MoveTypeStep},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			responder, _, _, _, _ := newTestFaultResponder(t)
+			m := &mockMoveMetrics{}
+			responder.metrics = m
+			require.NoError(t, responder.PerformAction(context.Background(), test.action))
+			require.Equal(t, []string{test.expected}, m.completed)
+			require.Equal(t, map[string]uint64{test.expected: mockMoveGasUsed}, m.gasUsed)
+		})
+	}
+
+	t.Run("SendFails", func(t *testing.T) {
+		responder, mockTxMgr, _, _, _ := newTestFaultResponder(t)
+		m := &mockMoveMetrics{}
+		responder.metrics = m
+		mockTxMgr.sendFails = true
+		err := responder.PerformAction(context.Background(), types.Action{Type: types.ActionTypeMove, IsAttack: true})
+		require.ErrorIs(t, err, mockSendError)
+		require.Empty(t, m.completed)
+		require.Empty(t, m.gasUsed)
+	})
+}
+
 func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract, *mockPreimageUploader, *mockOracle) {
 	log := testlog.Logger(t, log.LevelError)
 	mockTxMgr := &mockTxManager{}
 	contract := &mockContract{}
 	uploader := &mockPreimageUploader{}
 	oracle := &mockOracle{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, oracle)
+	responder, err := NewFaultResponder(log, &mockMoveMetrics{}, mockTxMgr, contract, uploader, oracle)
 	require.NoError(t, err)
 	return responder, mockTxMgr, contract, uploader, oracle
 }
@@ -324,6 +362,24 @@ func (m *mockOracle) GlobalDataExists(ctx context.Context, data *types.PreimageO
 	return m.existsResult, nil
 }
 
+type mockMoveMetrics struct {
+	completed []string
+	gasUsed   map[string]uint64
+}
+
+func (m *mockMoveMetrics) RecordChallengerMove(moveType string) func() {
+	return func() {
+		m.completed = append(m.completed, moveType)
+	}
+}
+
+func (m *mockMoveMetrics) RecordChallengerGasUsed(moveType string, gasUsed uint64) {
+	if m.gasUsed == nil {
+		m.gasUsed = make(map[string]uint64)
+	}
+	m.gasUsed[moveType] += gasUsed
+}
+
 type mockTxManager struct {
 	from      common.Address
 	sends     int
@@ -342,6 +398,15 @@ func (m *mockTxManager) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) er
 	return nil
 }
 
+func (m *mockTxManager) SendAndWaitReceipt(_ string, tx txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	if m.sendFails {
+		return nil, mockSendError
+	}
+	m.sends++
+	m.sent = append(m.sent, tx)
+	return &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful, GasUsed: mockMoveGasUsed}, nil
+}
+
 func (m *mockTxManager) BlockNumber(_ context.Context) (uint64, error) {
 	panic("not implemented")
 }
@@ -53,6 +53,7 @@ type gameMonitor struct {
 	claimer          claimer
 	fetchBlockNumber blockNumberFetcher
 	allowedGames     []common.Address
+	deniedGames      []common.Address
 	l1HeadsSub       ethereum.Subscription
 	l1Source         *headSource
 	runState         sync.Mutex
@@ -80,6 +81,7 @@ func newGameMonitor(
 	claimer claimer,
 	fetchBlockNumber blockNumberFetcher,
 	allowedGames []common.Address,
+	deniedGames []common.Address,
 	l1Source MinimalSubscriber,
 ) *gameMonitor {
 	return &gameMonitor{
@@ -92,11 +94,17 @@ func newGameMonitor(
 		claimer:          claimer,
 		fetchBlockNumber: fetchBlockNumber,
 		allowedGames:     allowedGames,
+		deniedGames:      deniedGames,
 		l1Source:         &headSource{inner: l1Source},
 	}
 }
 
 func (m *gameMonitor) allowedGame(game common.Address) bool {
+	for _, denied := range m.deniedGames {
+		if denied == game {
+			return false
+		}
+	}
 	if len(m.allowedGames) == 0 {
 		return true
 	}
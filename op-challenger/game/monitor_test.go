@@ -142,6 +142,33 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, 1, stubClaimer.scheduledGames)
 }
 
+func TestMonitorSkipsDeniedGame(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _, stubClaimer := setupMonitorTest(t, []common.Address{})
+	monitor.deniedGames = []common.Address{addr1}
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
+	require.Equal(t, 1, stubClaimer.scheduledGames)
+}
+
+func TestMonitorDenylistTakesPrecedence(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _, _ := setupMonitorTest(t, []common.Address{addr1, addr2})
+	monitor.deniedGames = []common.Address{addr2}
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr1}, sched.Scheduled()[0])
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -174,6 +201,7 @@ func setupMonitorTest(
 		stubClaimer,
 		fetchBlockNum,
 		allowedGames,
+		nil,
 		mockHeadSource,
 	)
 	return monitor, source, sched, mockHeadSource, preimages, stubClaimer
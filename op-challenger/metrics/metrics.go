@@ -32,6 +32,9 @@ type Metricer interface {
 	// Record contract metrics
 	contractMetrics.ContractMetricer
 
+	// Record move metrics
+	MoveMetricer
+
 	RecordActedL1Block(n uint64)
 
 	RecordGameStep()
@@ -69,6 +72,7 @@ type Metrics struct {
 	txmetrics.TxMetrics
 	*opmetrics.CacheMetrics
 	*contractMetrics.ContractMetrics
+	MoveMetrics
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
@@ -116,6 +120,8 @@ func NewMetrics() *Metrics {
 
 		ContractMetrics: contractMetrics.MakeContractMetrics(Namespace, factory),
 
+		MoveMetrics: MakeMoveMetrics(Namespace, factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "info",
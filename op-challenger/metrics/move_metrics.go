@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Move types recorded in the move_type label of the move metrics.
+const (
+	MoveTypeAttack = "attack"
+	MoveTypeDefend = "defend"
+	MoveTypeStep   = "step"
+)
+
+// defaultMoveDurationBuckets are the buckets of the move duration histogram.
+// Moves wait for their transaction receipt so take at least one L1 block.
+var defaultMoveDurationBuckets = []float64{1, 2.5, 5, 10, 15, 30, 60, 120, 300}
+
+type MoveMetricer interface {
+	RecordChallengerMove(moveType string) func()
+	RecordChallengerGasUsed(moveType string, gasUsed uint64)
+}
+
+// MoveMetrics tracks the moves made by the challenger.
+type MoveMetrics struct {
+	ChallengerMoveDurationSeconds *prometheus.HistogramVec
+	ChallengerGasUsed             *prometheus.HistogramVec
+}
+
+var _ MoveMetricer = (*MoveMetrics)(nil)
+
+type moveMetricsConfig struct {
+	moveDurationBuckets []float64
+}
+
+// MoveMetricsOption customises the metrics created by MakeMoveMetrics.
+type MoveMetricsOption func(cfg *moveMetricsConfig)
+
+// WithMoveDurationBuckets overrides the buckets of the move duration histogram.
+func WithMoveDurationBuckets(buckets []float64) MoveMetricsOption {
+	return func(cfg *moveMetricsConfig) {
+		cfg.moveDurationBuckets = buckets
+	}
+}
+
+// MakeMoveMetrics creates a new MoveMetrics instance with the given namespace
+// for the service.
+func MakeMoveMetrics(ns string, factory opmetrics.Factory, opts ...MoveMetricsOption) MoveMetrics {
+	cfg := moveMetricsConfig{
+		moveDurationBuckets: defaultMoveDurationBuckets,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return MoveMetrics{
+		ChallengerMoveDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "move_duration_seconds",
+			Buckets:   cfg.moveDurationBuckets,
+			Help:      "Histogram of the time taken from deciding on a move to its transaction receipt being confirmed",
+		}, []string{
+			"move_type",
+		}),
+		ChallengerGasUsed: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "move_gas_used",
+			// 25k to ~25M gas
+			Buckets: prometheus.ExponentialBuckets(25_000, 2, 11),
+			Help:    "Histogram of the gas used by move transactions",
+		}, []string{
+			"move_type",
+		}),
+	}
+}
+
+// RecordChallengerMove starts timing a move of the given type. The returned
+// function must be called once the move's transaction receipt is confirmed.
+func (m *MoveMetrics) RecordChallengerMove(moveType string) func() {
+	timer := prometheus.NewTimer(m.ChallengerMoveDurationSeconds.WithLabelValues(moveType))
+	return func() {
+		timer.ObserveDuration()
+	}
+}
+
+// RecordChallengerGasUsed records the gas used by the transaction for a move
+// of the given type, as reported by its receipt.
+func (m *MoveMetrics) RecordChallengerGasUsed(moveType string, gasUsed uint64) {
+	m.ChallengerGasUsed.WithLabelValues(moveType).Observe(float64(gasUsed))
+}
+
+type NoopMoveMetrics struct{}
+
+var _ MoveMetricer = (*NoopMoveMetrics)(nil)
+
+func (n *NoopMoveMetrics) RecordChallengerMove(moveType string) func() {
+	return func() {}
+}
+
+func (n *NoopMoveMetrics) RecordChallengerGasUsed(moveType string, gasUsed uint64) {
+}
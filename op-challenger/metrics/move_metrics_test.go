@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"testing"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func histogramOf(t *testing.T, observer prometheus.Observer) *dto.Histogram {
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram()
+}
+
+func TestRecordChallengerMove(t *testing.T) {
+	m := MakeMoveMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+
+	m.RecordChallengerMove(MoveTypeAttack)()
+	m.RecordChallengerMove(MoveTypeAttack)()
+	m.RecordChallengerMove(MoveTypeStep)()
+
+	require.Equal(t, uint64(2), histogramOf(t, m.ChallengerMoveDurationSeconds.WithLabelValues(MoveTypeAttack)).GetSampleCount())
+	require.Equal(t, uint64(0), histogramOf(t, m.ChallengerMoveDurationSeconds.WithLabelValues(MoveTypeDefend)).GetSampleCount())
+	require.Equal(t, uint64(1), histogramOf(t, m.ChallengerMoveDurationSeconds.WithLabelValues(MoveTypeStep)).GetSampleCount())
+}
+
+func TestMoveDurationBuckets(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		m := MakeMoveMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+		m.RecordChallengerMove(MoveTypeAttack)()
+		hist := histogramOf(t, m.ChallengerMoveDurationSeconds.WithLabelValues(MoveTypeAttack))
+		require.Len(t, hist.GetBucket(), len(defaultMoveDurationBuckets))
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		buckets := []float64{1, 10, 100}
+		m := MakeMoveMetrics("test", opmetrics.With(prometheus.NewRegistry()), WithMoveDurationBuckets(buckets))
+		m.RecordChallengerMove(MoveTypeAttack)()
+		hist := histogramOf(t, m.ChallengerMoveDurationSeconds.WithLabelValues(MoveTypeAttack))
+		require.Len(t, hist.GetBucket(), len(buckets))
+		require.Equal(t, 100.0, hist.GetBucket()[2].GetUpperBound())
+	})
+}
+
+func TestRecordChallengerGasUsed(t *testing.T) {
+	m := MakeMoveMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+
+	m.RecordChallengerGasUsed(MoveTypeAttack, 120_000)
+	m.RecordChallengerGasUsed(MoveTypeAttack, 80_000)
+
+	hist := histogramOf(t, m.ChallengerGasUsed.WithLabelValues(MoveTypeAttack))
+	require.Equal(t, uint64(2), hist.GetSampleCount())
+	require.Equal(t, 200_000.0, hist.GetSampleSum())
+	require.Equal(t, uint64(0), histogramOf(t, m.ChallengerGasUsed.WithLabelValues(MoveTypeStep)).GetSampleCount())
+}
@@ -34,15 +34,34 @@ func (s *TxSender) From() common.Address {
 }
 
 func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	_, errs := s.sendAndWait(txPurpose, txs...)
+	return errs
+}
+
+func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	errs := s.SendAndWaitDetailed(txPurpose, txs...)
+	return errors.Join(errs...)
+}
+
+// SendAndWaitReceipt sends a single transaction and waits for it to be included,
+// returning its receipt. The receipt is also returned if the transaction reverted.
+func (s *TxSender) SendAndWaitReceipt(txPurpose string, tx txmgr.TxCandidate) (*types.Receipt, error) {
+	rcpts, errs := s.sendAndWait(txPurpose, tx)
+	return rcpts[0], errs[0]
+}
+
+func (s *TxSender) sendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*types.Receipt, []error) {
 	receiptsCh := make(chan txmgr.TxReceipt[int], len(txs))
 	for i, tx := range txs {
 		s.queue.Send(i, tx, receiptsCh)
 	}
 	completed := 0
+	rcpts := make([]*types.Receipt, len(txs))
 	errs := make([]error, len(txs))
 	for completed < len(txs) {
 		rcpt := <-receiptsCh
 		completed++
+		rcpts[rcpt.ID] = rcpt.Receipt
 		if rcpt.Err != nil {
 			errs[rcpt.ID] = rcpt.Err
 		} else if rcpt.Receipt != nil {
@@ -53,10 +72,5 @@ func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidat
 			}
 		}
 	}
-	return errs
-}
-
-func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
-	errs := s.SendAndWaitDetailed(txPurpose, txs...)
-	return errors.Join(errs...)
+	return rcpts, errs
 }
@@ -112,6 +112,31 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 	require.NoError(t, errs[2])
 }
 
+func TestSendAndWaitReceipt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	txMgr := &stubTxMgr{
+		sending: make(map[byte]chan *types.Receipt),
+		syncStatus: map[byte]uint64{
+			0: types.ReceiptStatusSuccessful,
+			1: types.ReceiptStatusFailed,
+		},
+	}
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500)
+
+	tx := func(i byte) txmgr.TxCandidate {
+		return txmgr.TxCandidate{TxData: []byte{i}}
+	}
+
+	rcpt, err := sender.SendAndWaitReceipt("testing", tx(0))
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, rcpt.Status)
+
+	rcpt, err = sender.SendAndWaitReceipt("testing", tx(1))
+	require.ErrorIs(t, err, ErrTransactionReverted)
+	require.Equal(t, types.ReceiptStatusFailed, rcpt.Status)
+}
+
 type stubTxMgr struct {
 	m          sync.Mutex
 	sending    map[byte]chan *types.Receipt
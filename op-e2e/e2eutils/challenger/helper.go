@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -20,6 +23,7 @@ import (
 
 	challenger "github.com/ethereum-optimism/optimism/op-challenger"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -34,9 +38,11 @@ type EndpointProvider interface {
 
 type Helper struct {
 	log     log.Logger
+	logs    *capturedLogs
 	t       *testing.T
 	require *require.Assertions
 	dir     string
+	cfg     *config.Config
 	chl     cliapp.Lifecycle
 }
 
@@ -64,18 +70,35 @@ func WithGameAddress(addr common.Address) Option {
 	}
 }
 
+func WithDeniedGame(addr common.Address) Option {
+	return func(c *config.Config) {
+		c.GameDenylist = append(c.GameDenylist, addr)
+	}
+}
+
 func WithPrivKey(key *ecdsa.PrivateKey) Option {
 	return func(c *config.Config) {
 		c.TxMgrConfig.PrivateKey = e2eutils.EncodePrivKeyToString(key)
 	}
 }
 
+// WithPollInterval sets how often the challenger polls L1 for new blocks when connected over HTTP. If not set the
+// production default, config.DefaultPollInterval, is used. Tests that need the challenger to respond quickly, such as
+// those running in CI, can use a short interval like 100ms.
 func WithPollInterval(pollInterval time.Duration) Option {
 	return func(c *config.Config) {
 		c.PollInterval = pollInterval
 	}
 }
 
+// WithValidPrestateRequired disables AllowInvalidPrestate so the challenger refuses to act on games where the
+// on-chain absolute prestate does not match its configured prestate.
+func WithValidPrestateRequired() Option {
+	return func(c *config.Config) {
+		c.AllowInvalidPrestate = false
+	}
+}
+
 // FindMonorepoRoot finds the relative path to the monorepo root
 // Different tests might be nested in subdirectories of the op-e2e dir.
 func FindMonorepoRoot(t *testing.T) string {
@@ -145,7 +168,9 @@ func WithAlphabet(rollupEndpoint string) Option {
 }
 
 func NewChallenger(t *testing.T, ctx context.Context, sys EndpointProvider, name string, options ...Option) *Helper {
-	log := testlog.Logger(t, log.LevelDebug).New("role", name)
+	logger, capture := testlog.CaptureLogger(t, log.LevelDebug)
+	logs := &capturedLogs{handler: capture}
+	log := log.NewLogger(&lockedHandler{mu: &logs.mu, Handler: logger.Handler()}).New("role", name)
 	log.Info("Creating challenger")
 	cfg := NewChallengerConfig(t, sys, options...)
 	chl, err := challenger.Main(ctx, log, cfg)
@@ -154,9 +179,11 @@ func NewChallenger(t *testing.T, ctx context.Context, sys EndpointProvider, name
 
 	return &Helper{
 		log:     log,
+		logs:    logs,
 		t:       t,
 		require: require.New(t),
 		dir:     cfg.Datadir,
+		cfg:     cfg,
 		chl:     chl,
 	}
 }
@@ -198,10 +225,6 @@ func NewChallengerConfig(t *testing.T, sys EndpointProvider, options ...Option)
 		_, err := os.Stat(cfg.CannonAbsolutePreState)
 		require.NoError(t, err, "cannon pre-state should be built. Make sure you've run make cannon-prestate")
 	}
-	if cfg.PollInterval == 0 {
-		cfg.PollInterval = time.Second
-	}
-
 	return &cfg
 }
 
@@ -211,6 +234,58 @@ func (h *Helper) Close() error {
 	return h.chl.Stop(ctx)
 }
 
+// RestartManyTimes stops and restarts the challenger n times in a row, reusing the same config and data directory.
+func (h *Helper) RestartManyTimes(ctx context.Context, n int) {
+	h.require.NotNil(h.cfg, "Challenger config is required to restart")
+	for i := 0; i < n; i++ {
+		h.log.Info("Restarting challenger", "restart", i+1, "total", n)
+		h.require.NoError(h.Close(), "must stop challenger")
+		chl, err := challenger.Main(ctx, h.log, h.cfg)
+		h.require.NoError(err, "must init challenger")
+		h.require.NoError(chl.Start(ctx), "must start challenger")
+		h.chl = chl
+	}
+}
+
+// UpgradeChallenger stops the challenger and starts a new instance using the existing config with newOpts applied,
+// simulating an upgrade of the challenger mid-game. The data directory is preserved.
+func (h *Helper) UpgradeChallenger(ctx context.Context, newOpts ...Option) {
+	h.require.NotNil(h.cfg, "Challenger config is required to upgrade")
+	cfg := *h.cfg
+	for _, option := range newOpts {
+		option(&cfg)
+	}
+	h.require.NoError(cfg.Check(), "upgraded op-challenger config should be valid")
+
+	h.log.Info("Upgrading challenger")
+	h.require.NoError(h.Close(), "must stop challenger")
+	chl, err := challenger.Main(ctx, h.log, &cfg)
+	h.require.NoError(err, "must init upgraded challenger")
+	h.require.NoError(chl.Start(ctx), "must start upgraded challenger")
+	h.cfg = &cfg
+	h.chl = chl
+}
+
+// WaitForInvalidPrestate waits for the challenger to report that it could not schedule the game because the named
+// prestate (e.g. "alphabet" or "output root") does not match the game. Only supported for challengers created by
+// NewChallenger as it relies on the captured challenger logs.
+func (h *Helper) WaitForInvalidPrestate(ctx context.Context, game common.Address, valueName string) {
+	h.require.NotNil(h.logs, "Challenger logs must be captured to detect invalid prestates")
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	err := wait.For(ctx, time.Second, func() (bool, error) {
+		return h.logs.find(
+			testlog.NewMessageFilter("Failed to schedule game updates"),
+			func(r *slog.Record) bool {
+				err, ok := testlog.HelperRecord{Record: r}.AttrValue("err").(error)
+				return ok && errors.Is(err, gameTypes.ErrInvalidPrestate) &&
+					strings.Contains(err.Error(), game.Hex()) &&
+					strings.Contains(err.Error(), valueName+" "+gameTypes.ErrInvalidPrestate.Error())
+			}) != nil, nil
+	})
+	h.require.NoErrorf(err, "Challenger did not reject game %v for an invalid %v prestate", game, valueName)
+}
+
 type GameAddr interface {
 	Addr() common.Address
 }
@@ -248,3 +323,36 @@ func (h *Helper) WaitForGameDataDeletion(ctx context.Context, games ...GameAddr)
 func (h *Helper) gameDataDir(addr common.Address) string {
 	return filepath.Join(h.dir, "game-"+addr.Hex())
 }
+
+// capturedLogs holds the logs captured from a challenger. The challenger logs from multiple goroutines so all access
+// to the capturing handler is guarded by mu.
+type capturedLogs struct {
+	mu      sync.Mutex
+	handler *testlog.CapturingHandler
+}
+
+func (l *capturedLogs) find(filters ...testlog.LogFilter) *testlog.HelperRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.handler.FindLog(filters...)
+}
+
+// lockedHandler serialises calls to the wrapped handler so it can be shared by the challenger's goroutines.
+type lockedHandler struct {
+	mu *sync.Mutex
+	slog.Handler
+}
+
+func (h *lockedHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *lockedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &lockedHandler{mu: h.mu, Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *lockedHandler) WithGroup(name string) slog.Handler {
+	return &lockedHandler{mu: h.mu, Handler: h.Handler.WithGroup(name)}
+}
@@ -198,7 +198,7 @@ func (g *FaultGameHelper) WaitForGameStatus(ctx context.Context, expected Status
 			return false, fmt.Errorf("game status unavailable: %w", err)
 		}
 		g.t.Logf("Game %v has state %v, waiting for state %v", g.addr, Status(status), expected)
-		return expected == Status(status), nil
+		return checkGameStatus(Status(status), expected)
 	})
 	g.require.NoErrorf(err, "wait for game status. Game state: \n%v", g.gameData(ctx))
 }
@@ -2,6 +2,7 @@ package disputegame
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/binary"
 	"fmt"
 	"math/big"
@@ -38,9 +39,8 @@ var (
 )
 
 const (
-	cannonGameType    uint32 = 0
-	alphabetGameType  uint32 = 255
-	alphabetGameDepth        = 4
+	cannonGameType   uint32 = 0
+	alphabetGameType uint32 = 255
 )
 
 type Status uint8
@@ -64,6 +64,18 @@ func (s Status) String() string {
 	}
 }
 
+// checkGameStatus reports whether the game has reached the expected status.
+// Once a game is resolved its status can't change, so reaching any other terminal status is an error.
+func checkGameStatus(actual Status, expected Status) (bool, error) {
+	if actual == expected {
+		return true, nil
+	}
+	if actual != StatusInProgress {
+		return false, fmt.Errorf("game resolved to %v, expected %v", actual, expected)
+	}
+	return false, nil
+}
+
 type GameCfg struct {
 	allowFuture bool
 	allowUnsafe bool
@@ -111,9 +123,89 @@ type FactoryHelper struct {
 	Opts        *bind.TransactOpts
 	FactoryAddr common.Address
 	Factory     *bindings.DisputeGameFactory
+
+	gameDuration time.Duration
+}
+
+// FactoryCfg holds the changes NewFactoryHelper makes to the game implementations deployed when the system started.
+type FactoryCfg struct {
+	ownerKey     *ecdsa.PrivateKey
+	alphabetImpl []func(p *gameImplParams)
+}
+type FactoryOpt interface {
+	Apply(cfg *FactoryCfg)
+}
+type factoryOptFn func(c *FactoryCfg)
+
+func (f factoryOptFn) Apply(cfg *FactoryCfg) {
+	f(cfg)
+}
+
+// WithFactoryOwner sets the key of the only signer of the Safe that owns the dispute game factory. It is required by
+// the options that replace a game implementation. In the devnet this is the key that deployed the L1 contracts,
+// sys.Cfg.Secrets.CliqueSigner.
+func WithFactoryOwner(key *ecdsa.PrivateKey) FactoryOpt {
+	return factoryOptFn(func(c *FactoryCfg) {
+		c.ownerKey = key
+	})
+}
+
+// WithAlphabetGameDepth replaces the alphabet game implementation registered with the factory with one using the
+// specified max game depth and split depth. Every other parameter is copied from the deployed implementation.
+func WithAlphabetGameDepth(maxGameDepth uint64, splitDepth uint64) FactoryOpt {
+	return factoryOptFn(func(c *FactoryCfg) {
+		c.alphabetImpl = append(c.alphabetImpl, func(p *gameImplParams) {
+			p.maxGameDepth = new(big.Int).SetUint64(maxGameDepth)
+			p.splitDepth = new(big.Int).SetUint64(splitDepth)
+		})
+	})
 }
 
-func NewFactoryHelper(t *testing.T, ctx context.Context, system DisputeSystem) *FactoryHelper {
+// WithAlphabetVM replaces the alphabet game implementation registered with the factory with one that uses the VM at
+// vm, simulating a factory misconfigured with the wrong VM.
+func WithAlphabetVM(vm common.Address) FactoryOpt {
+	return factoryOptFn(func(c *FactoryCfg) {
+		c.alphabetImpl = append(c.alphabetImpl, func(p *gameImplParams) {
+			p.vm = vm
+		})
+	})
+}
+
+// WithAlphabetAbsolutePrestate replaces the alphabet game implementation registered with the factory with one that
+// uses prestate as its absolute prestate, simulating a factory misconfigured with the wrong prestate.
+func WithAlphabetAbsolutePrestate(prestate common.Hash) FactoryOpt {
+	return factoryOptFn(func(c *FactoryCfg) {
+		c.alphabetImpl = append(c.alphabetImpl, func(p *gameImplParams) {
+			p.absolutePrestate = prestate
+		})
+	})
+}
+
+// NewFactoryHelper creates a FactoryHelper for the dispute game factory deployed when the system started.
+// Without options the game implementations are used as deployed, otherwise the alphabet game implementation is
+// redeployed with the requested changes and registered with the factory before the helper is returned.
+func NewFactoryHelper(t *testing.T, ctx context.Context, system DisputeSystem, opts ...FactoryOpt) *FactoryHelper {
+	h := AttachFactoryHelper(t, ctx, system, system.L1Deployments().DisputeGameFactoryProxy)
+	cfg := &FactoryCfg{}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+	if len(cfg.alphabetImpl) > 0 {
+		h.Require.NotNil(cfg.ownerKey, "WithFactoryOwner is required to replace the alphabet game implementation")
+		params := h.loadGameImplParams(ctx, alphabetGameType)
+		for _, apply := range cfg.alphabetImpl {
+			apply(&params)
+		}
+		h.replaceGameImpl(ctx, alphabetGameType, params, cfg.ownerKey)
+	}
+	h.gameDuration = h.loadGameDuration(ctx, alphabetGameType)
+	return h
+}
+
+// AttachFactoryHelper creates a FactoryHelper for the dispute game factory already deployed at factoryAddr on the
+// system's L1, such as one used by a previous step of the test. NewFactoryHelper attaches to the factory deployed
+// when the system started.
+func AttachFactoryHelper(t *testing.T, ctx context.Context, system DisputeSystem, factoryAddr common.Address) *FactoryHelper {
 	require := require.New(t)
 	client := system.NodeClient("l1")
 	chainID, err := client.ChainID(ctx)
@@ -121,8 +213,6 @@ func NewFactoryHelper(t *testing.T, ctx context.Context, system DisputeSystem) *
 	opts, err := bind.NewKeyedTransactorWithChainID(TestKey, chainID)
 	require.NoError(err)
 
-	l1Deployments := system.L1Deployments()
-	factoryAddr := l1Deployments.DisputeGameFactoryProxy
 	factory, err := bindings.NewDisputeGameFactory(factoryAddr, client)
 	require.NoError(err)
 
@@ -152,6 +242,41 @@ func (h *FactoryHelper) PreimageHelper(ctx context.Context) *preimage.Helper {
 	return preimage.NewHelper(h.T, h.Opts, h.Client, oracleAddr)
 }
 
+// GameDuration returns the max clock duration of the games created by the factory, as loaded by NewFactoryHelper.
+// Once a claim's clock has accumulated this much time without being countered, it can be resolved.
+// It is zero for helpers created with AttachFactoryHelper.
+func (h *FactoryHelper) GameDuration() time.Duration {
+	return h.gameDuration
+}
+
+// loadGameDuration loads the max clock duration of the game implementation registered with the factory for gameType.
+func (h *FactoryHelper) loadGameDuration(ctx context.Context, gameType uint32) time.Duration {
+	opts := &bind.CallOpts{Context: ctx}
+	implAddr, err := h.Factory.GameImpls(opts, gameType)
+	h.Require.NoError(err, "Failed to load game implementation")
+	impl, err := bindings.NewFaultDisputeGameCaller(implAddr, h.Client)
+	h.Require.NoError(err)
+	duration, err := impl.MaxClockDuration(opts)
+	h.Require.NoError(err, "Failed to load max clock duration")
+	return time.Duration(duration) * time.Second
+}
+
+// GameImplConfig returns the VM address and absolute prestate of the implementation registered with the factory for
+// gameType. These are the values deployed with the rest of the L1 contracts unless overridden by the options passed to
+// NewFactoryHelper.
+func (h *FactoryHelper) GameImplConfig(ctx context.Context, gameType uint32) (common.Address, common.Hash) {
+	implAddr, err := h.Factory.GameImpls(&bind.CallOpts{Context: ctx}, gameType)
+	h.Require.NoErrorf(err, "Failed to load implementation for game type %v", gameType)
+	h.Require.NotEqualf(common.Address{}, implAddr, "No implementation registered for game type %v", gameType)
+	impl, err := bindings.NewFaultDisputeGameCaller(implAddr, h.Client)
+	h.Require.NoError(err)
+	vm, err := impl.Vm(&bind.CallOpts{Context: ctx})
+	h.Require.NoErrorf(err, "Failed to load VM for game type %v", gameType)
+	prestate, err := impl.AbsolutePrestate(&bind.CallOpts{Context: ctx})
+	h.Require.NoErrorf(err, "Failed to load absolute prestate for game type %v", gameType)
+	return vm, prestate
+}
+
 func NewGameCfg(opts ...GameOpt) *GameCfg {
 	cfg := &GameCfg{}
 	for _, opt := range opts {
@@ -170,23 +295,33 @@ func (h *FactoryHelper) StartOutputCannonGameWithCorrectRoot(ctx context.Context
 
 func (h *FactoryHelper) StartOutputCannonGame(ctx context.Context, l2Node string, l2BlockNumber uint64, rootClaim common.Hash, opts ...GameOpt) *OutputCannonGameHelper {
 	cfg := NewGameCfg(opts...)
-	logger := testlog.Logger(h.T, log.LevelInfo).New("role", "OutputCannonGameHelper")
-	rollupClient := h.System.RollupClient(l2Node)
-
 	extraData := h.CreateBisectionGameExtraData(l2Node, l2BlockNumber, cfg)
+	return &OutputCannonGameHelper{
+		OutputGameHelper: *h.StartGame(ctx, l2Node, cannonGameType, rootClaim, extraData),
+	}
+}
+
+// StartGame creates a new game of gameType using the supplied root claim and extra data, and returns a helper bound
+// to the created game proxy. gameType must be an output root based game and its implementation must be registered
+// with the factory. It is a lower level primitive than the StartOutput*Game methods, allowing games to be created
+// for any registered game type.
+func (h *FactoryHelper) StartGame(ctx context.Context, l2Node string, gameType uint32, rootClaim common.Hash, extraData []byte) *OutputGameHelper {
+	logger := testlog.Logger(h.T, log.LevelInfo).New("role", "OutputGameHelper", "gameType", gameType)
+	rollupClient := h.System.RollupClient(l2Node)
 
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
 	tx, err := transactions.PadGasEstimate(h.Opts, 2, func(opts *bind.TransactOpts) (*types.Transaction, error) {
-		return h.Factory.Create(opts, cannonGameType, rootClaim, extraData)
+		return h.Factory.Create(opts, gameType, rootClaim, extraData)
 	})
-	h.Require.NoError(err, "create fault dispute game")
+	h.Require.NoErrorf(err, "create game of type %v", gameType)
 	rcpt, err := wait.ForReceiptOK(ctx, h.Client, tx.Hash())
-	h.Require.NoError(err, "wait for create fault dispute game receipt to be OK")
+	h.Require.NoErrorf(err, "wait for create game of type %v receipt to be OK", gameType)
 	h.Require.Len(rcpt.Logs, 2, "should have emitted a single DisputeGameCreated event")
 	createdEvent, err := h.Factory.ParseDisputeGameCreated(*rcpt.Logs[1])
 	h.Require.NoError(err)
+	h.T.Logf("Created game %v of type %v with root claim %v", createdEvent.DisputeProxy, gameType, rootClaim)
 	game, err := bindings.NewFaultDisputeGame(createdEvent.DisputeProxy, h.Client)
 	h.Require.NoError(err)
 
@@ -202,9 +337,7 @@ func (h *FactoryHelper) StartOutputCannonGame(ctx context.Context, l2Node string
 	prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock.Uint64())
 	provider := outputs.NewTraceProvider(logger, prestateProvider, rollupClient, l1Head, faultTypes.Depth(splitDepth.Uint64()), prestateBlock.Uint64(), poststateBlock.Uint64())
 
-	return &OutputCannonGameHelper{
-		OutputGameHelper: *NewOutputGameHelper(h.T, h.Require, h.Client, h.Opts, game, h.FactoryAddr, createdEvent.DisputeProxy, provider, h.System),
-	}
+	return NewOutputGameHelper(h.T, h.Require, h.Client, h.Opts, game, h.FactoryAddr, createdEvent.DisputeProxy, provider, h.System)
 }
 
 func (h *FactoryHelper) GetL1Head(ctx context.Context, game *bindings.FaultDisputeGame) eth.BlockID {
@@ -221,45 +354,134 @@ func (h *FactoryHelper) StartOutputAlphabetGameWithCorrectRoot(ctx context.Conte
 	h.WaitForBlock(l2Node, l2BlockNumber, cfg)
 	output, err := h.System.RollupClient(l2Node).OutputAtBlock(ctx, l2BlockNumber)
 	h.Require.NoErrorf(err, "Failed to get output at block %v", l2BlockNumber)
-	return h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, common.Hash(output.OutputRoot))
+	return h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, common.Hash(output.OutputRoot), opts...)
 }
 
 func (h *FactoryHelper) StartOutputAlphabetGame(ctx context.Context, l2Node string, l2BlockNumber uint64, rootClaim common.Hash, opts ...GameOpt) *OutputAlphabetGameHelper {
 	cfg := NewGameCfg(opts...)
-	logger := testlog.Logger(h.T, log.LevelInfo).New("role", "OutputAlphabetGameHelper")
-	rollupClient := h.System.RollupClient(l2Node)
-
 	extraData := h.CreateBisectionGameExtraData(l2Node, l2BlockNumber, cfg)
+	return &OutputAlphabetGameHelper{
+		OutputGameHelper: *h.StartGame(ctx, l2Node, alphabetGameType, rootClaim, extraData),
+	}
+}
 
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
+// StartTwoGamesSameOutput creates two output alphabet games that dispute the same L2 block with different invalid
+// root claims so the challenger has to act on each independently.
+func (h *FactoryHelper) StartTwoGamesSameOutput(ctx context.Context, l2Node string, l2BlockNumber uint64) (*OutputAlphabetGameHelper, *OutputAlphabetGameHelper) {
+	game1 := h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, common.Hash{0xaa, 0x01})
+	game2 := h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, common.Hash{0xaa, 0x02})
+	return game1, game2
+}
 
-	tx, err := transactions.PadGasEstimate(h.Opts, 2, func(opts *bind.TransactOpts) (*types.Transaction, error) {
-		return h.Factory.Create(opts, alphabetGameType, rootClaim, extraData)
+// AssertChallengerActsOnBoth waits for the challenger to counter the root claim of both games.
+func (h *FactoryHelper) AssertChallengerActsOnBoth(ctx context.Context, game1 *OutputAlphabetGameHelper, game2 *OutputAlphabetGameHelper) {
+	game1.RootClaim(ctx).WaitForCounterClaim(ctx)
+	game2.RootClaim(ctx).WaitForCounterClaim(ctx)
+}
+
+// CreateManyGames creates n output alphabet games for the same L2 block, each with a distinct invalid root claim.
+func (h *FactoryHelper) CreateManyGames(ctx context.Context, l2Node string, l2BlockNumber uint64, n int) []*OutputAlphabetGameHelper {
+	games := make([]*OutputAlphabetGameHelper, 0, n)
+	for i := 0; i < n; i++ {
+		rootClaim := common.Hash{0xff, byte(i >> 8), byte(i)}
+		games = append(games, h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, rootClaim))
+	}
+	return games
+}
+
+// AssertChallengerProcessesAllWithin waits for the root claim of every supplied game to be countered, failing if
+// any remain uncountered once the within duration has elapsed.
+func (h *FactoryHelper) AssertChallengerProcessesAllWithin(ctx context.Context, within time.Duration, games ...*OutputAlphabetGameHelper) {
+	timedCtx, cancel := context.WithTimeout(ctx, within)
+	defer cancel()
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		pending := 0
+		for _, game := range games {
+			count, err := game.Game.ClaimDataLen(&bind.CallOpts{Context: timedCtx})
+			if err != nil {
+				return false, fmt.Errorf("retrieve number of claims for game %v: %w", game.Addr, err)
+			}
+			if count.Cmp(big.NewInt(1)) <= 0 {
+				pending++
+			}
+		}
+		h.T.Logf("Waiting for challenger to respond to %v of %v games", pending, len(games))
+		return pending == 0, nil
 	})
-	h.Require.NoError(err, "create output bisection game")
-	rcpt, err := wait.ForReceiptOK(ctx, h.Client, tx.Hash())
-	h.Require.NoError(err, "wait for create output bisection game receipt to be OK")
-	h.Require.Len(rcpt.Logs, 2, "should have emitted a single DisputeGameCreated event")
-	createdEvent, err := h.Factory.ParseDisputeGameCreated(*rcpt.Logs[1])
-	h.Require.NoError(err)
-	game, err := bindings.NewFaultDisputeGame(createdEvent.DisputeProxy, h.Client)
-	h.Require.NoError(err)
+	h.Require.NoErrorf(err, "Challenger did not respond to all %v games within %v", len(games), within)
+}
 
-	callOpts := &bind.CallOpts{Context: ctx}
-	prestateBlock, err := game.StartingBlockNumber(callOpts)
-	h.Require.NoError(err, "Failed to load starting block number")
-	poststateBlock, err := game.L2BlockNumber(callOpts)
-	h.Require.NoError(err, "Failed to load l2 block number")
-	splitDepth, err := game.SplitDepth(callOpts)
-	h.Require.NoError(err, "Failed to load split depth")
-	l1Head := h.GetL1Head(ctx, game)
-	prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock.Uint64())
+// resolutionGasClaimCounts are the numbers of counter claims posted in each game by AssertResolutionGasScaling.
+var resolutionGasClaimCounts = []int{4, 8, 16}
+
+// AssertResolutionGasScaling creates games for l2BlockNumber with an increasing number of claims, each attacking the
+// root claim, resolves them and measures the total gas used by the resolveClaim calls. Resolution gas must grow
+// sub-quadratically with the number of claims between each consecutive pair of games.
+func (h *FactoryHelper) AssertResolutionGasScaling(ctx context.Context, l2Node string, l2BlockNumber uint64) {
+	games := make([]*OutputAlphabetGameHelper, len(resolutionGasClaimCounts))
+	for i, count := range resolutionGasClaimCounts {
+		games[i] = h.StartOutputAlphabetGame(ctx, l2Node, l2BlockNumber, common.Hash{0xff, byte(i)})
+		for j := 0; j < count; j++ {
+			games[i].Attack(ctx, 0, common.Hash{0xaa, byte(j)})
+		}
+	}
 
-	provider := outputs.NewTraceProvider(logger, prestateProvider, rollupClient, l1Head, faultTypes.Depth(splitDepth.Uint64()), prestateBlock.Uint64(), poststateBlock.Uint64())
+	h.System.AdvanceTime(games[0].MaxClockDuration(ctx))
+	h.Require.NoError(wait.ForNextBlock(ctx, h.Client))
+	claims := make([]int64, len(games))
+	totals := make([]uint64, len(games))
+	for i, game := range games {
+		claims[i] = game.getClaimCount(ctx)
+		for _, used := range game.resolveAndMeasureGas(ctx) {
+			totals[i] += used
+		}
+		h.T.Logf("Resolving game with %v claims used %v gas", claims[i], totals[i])
+	}
 
-	return &OutputAlphabetGameHelper{
-		OutputGameHelper: *NewOutputGameHelper(h.T, h.Require, h.Client, h.Opts, game, h.FactoryAddr, createdEvent.DisputeProxy, provider, h.System),
+	for i := 1; i < len(games); i++ {
+		claimRatio := float64(claims[i]) / float64(claims[i-1])
+		gasRatio := float64(totals[i]) / float64(totals[i-1])
+		h.Require.Lessf(gasRatio, claimRatio*claimRatio,
+			"Resolution gas grew quadratically: %v claims used %v gas, %v claims used %v gas",
+			claims[i-1], totals[i-1], claims[i], totals[i])
+	}
+}
+
+// AssertDeterministicResolution plays runs games with identical inputs and checks that every game resolves with the
+// same winner, as predicted by AssertResolutionMatchesTrace. Every game has the correct output root as its root claim
+// and its last block is disputed with a bottom game whose trace is claimedAlphabet. honest indicates whether an honest
+// challenger plays each game. The factory rejects a second game with the same root claim and extra data, so each run
+// disputes the next L2 block. Each challenger is stopped before the next run starts so runs using the same key don't
+// compete for nonces.
+func (h *FactoryHelper) AssertDeterministicResolution(ctx context.Context, claimedAlphabet string, honest bool, runs int, options ...challenger.Option) {
+	h.Require.Positive(runs, "Must play at least one game")
+	var expected Status
+	for i := 0; i < runs; i++ {
+		game := h.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", uint64(3+i))
+		bottomRoot := game.AttackWithAlphabet(ctx, game.DisputeLastBlock(ctx), claimedAlphabet)
+		var c *challenger.Helper
+		if honest {
+			c = game.StartChallenger(ctx, "sequencer", fmt.Sprintf("Challenger-%v", i), options...)
+			bottomRoot.WaitForCounterClaim(ctx)
+			game.WaitForInactivity(ctx, 10, true)
+		}
+		h.System.AdvanceTime(game.MaxClockDuration(ctx))
+		h.Require.NoError(wait.ForNextBlock(ctx, h.Client))
+		if !honest {
+			game.ResolveExternally(ctx)
+		}
+		game.AssertResolutionMatchesTrace(ctx, claimedAlphabet, honest)
+		if c != nil {
+			h.Require.NoErrorf(c.Close(), "Failed to stop challenger for run %v", i+1)
+		}
+
+		status := game.Status(ctx)
+		h.T.Logf("Run %v of %v resolved with status %v", i+1, runs, status)
+		if i == 0 {
+			expected = status
+			continue
+		}
+		h.Require.Equalf(expected, status, "Run %v resolved differently to the first run", i+1)
 	}
 }
 
@@ -287,6 +509,124 @@ func (h *FactoryHelper) WaitForBlock(l2Node string, l2BlockNumber uint64, cfg *G
 	}
 }
 
+// gameImplParams are the constructor arguments of a FaultDisputeGame implementation.
+type gameImplParams struct {
+	absolutePrestate    common.Hash
+	maxGameDepth        *big.Int
+	splitDepth          *big.Int
+	clockExtension      uint64
+	maxClockDuration    uint64
+	vm                  common.Address
+	weth                common.Address
+	anchorStateRegistry common.Address
+	l2ChainID           *big.Int
+}
+
+// loadGameImplParams loads the constructor arguments of the implementation registered with the factory for gameType.
+func (h *FactoryHelper) loadGameImplParams(ctx context.Context, gameType uint32) gameImplParams {
+	opts := &bind.CallOpts{Context: ctx}
+	implAddr, err := h.Factory.GameImpls(opts, gameType)
+	h.Require.NoErrorf(err, "Failed to load implementation for game type %v", gameType)
+	impl, err := bindings.NewFaultDisputeGameCaller(implAddr, h.Client)
+	h.Require.NoError(err)
+
+	var params gameImplParams
+	params.absolutePrestate, err = impl.AbsolutePrestate(opts)
+	h.Require.NoError(err, "Failed to load absolute prestate")
+	params.maxGameDepth, err = impl.MaxGameDepth(opts)
+	h.Require.NoError(err, "Failed to load max game depth")
+	params.splitDepth, err = impl.SplitDepth(opts)
+	h.Require.NoError(err, "Failed to load split depth")
+	params.clockExtension, err = impl.ClockExtension(opts)
+	h.Require.NoError(err, "Failed to load clock extension")
+	params.maxClockDuration, err = impl.MaxClockDuration(opts)
+	h.Require.NoError(err, "Failed to load max clock duration")
+	params.vm, err = impl.Vm(opts)
+	h.Require.NoError(err, "Failed to load VM address")
+	params.weth, err = impl.Weth(opts)
+	h.Require.NoError(err, "Failed to load WETH address")
+	params.anchorStateRegistry, err = impl.AnchorStateRegistry(opts)
+	h.Require.NoError(err, "Failed to load anchor state registry address")
+	params.l2ChainID, err = impl.L2ChainId(opts)
+	h.Require.NoError(err, "Failed to load L2 chain ID")
+	return params
+}
+
+func (h *FactoryHelper) deployGameImpl(gameType uint32, params gameImplParams) (common.Address, *types.Transaction, error) {
+	addr, tx, _, err := bindings.DeployFaultDisputeGame(h.Opts, h.Client, gameType, params.absolutePrestate,
+		params.maxGameDepth, params.splitDepth, params.clockExtension, params.maxClockDuration, params.vm, params.weth,
+		params.anchorStateRegistry, params.l2ChainID)
+	return addr, tx, err
+}
+
+// replaceGameImpl deploys a new FaultDisputeGame implementation for gameType using params and registers it with the
+// factory. ownerKey must be the only signer of the Safe that owns the factory. Games created after this use the new
+// implementation.
+func (h *FactoryHelper) replaceGameImpl(ctx context.Context, gameType uint32, params gameImplParams, ownerKey *ecdsa.PrivateKey) {
+	implAddr, tx, err := h.deployGameImpl(gameType, params)
+	h.Require.NoErrorf(err, "Failed to deploy implementation for game type %v", gameType)
+	_, err = wait.ForReceiptOK(ctx, h.Client, tx.Hash())
+	h.Require.NoErrorf(err, "Implementation for game type %v was not deployed", gameType)
+
+	// The factory is owned by a Safe so setImplementation is called through it, signed by its owner as the sender of
+	// the transaction.
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := h.Factory.Owner(opts)
+	h.Require.NoError(err, "Failed to load factory owner")
+	safe, err := bindings.NewSafe(owner, h.Client)
+	h.Require.NoError(err)
+	chainID, err := h.Client.ChainID(ctx)
+	h.Require.NoError(err)
+	ownerOpts, err := bind.NewKeyedTransactorWithChainID(ownerKey, chainID)
+	h.Require.NoError(err)
+	ownerOpts.Context = ctx
+	signers, err := safe.GetOwners(opts)
+	h.Require.NoError(err, "Failed to load factory owner signers")
+	h.Require.Equal([]common.Address{ownerOpts.From}, signers, "Factory owner must be signed for by the owner key")
+
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	h.Require.NoError(err)
+	data, err := factoryAbi.Pack("setImplementation", gameType, implAddr)
+	h.Require.NoError(err)
+	signature := append(common.LeftPadBytes(ownerOpts.From.Bytes(), 32), make([]byte, 32)...)
+	signature = append(signature, 1)
+	tx, err = safe.ExecTransaction(ownerOpts, h.FactoryAddr, common.Big0, data, 0, common.Big0, common.Big0,
+		common.Big0, common.Address{}, common.Address{}, signature)
+	h.Require.NoErrorf(err, "Failed to send setImplementation for game type %v", gameType)
+	_, err = wait.ForReceiptOK(ctx, h.Client, tx.Hash())
+	h.Require.NoErrorf(err, "setImplementation for game type %v failed", gameType)
+
+	registered, err := h.Factory.GameImpls(opts, gameType)
+	h.Require.NoError(err)
+	h.Require.Equalf(implAddr, registered, "Implementation for game type %v was not registered", gameType)
+	h.T.Logf("Registered implementation %v for game type %v", implAddr, gameType)
+}
+
+// RequireRejectsShallowDepth attempts to deploy a new FaultDisputeGame implementation for gameType with the specified
+// max game depth, copying every other parameter from the implementation currently registered with the factory.
+// The depth must be shallow enough to leave no room for the execution trace game below the split depth, so the
+// deployment is expected to revert with InvalidSplitDepth.
+func (h *FactoryHelper) RequireRejectsShallowDepth(ctx context.Context, gameType uint32, depth uint64) {
+	params := h.loadGameImplParams(ctx, gameType)
+	h.Require.LessOrEqualf(depth, params.splitDepth.Uint64(), "Depth %v is deep enough to be valid with split depth %v", depth, params.splitDepth)
+	params.maxGameDepth = new(big.Int).SetUint64(depth)
+
+	_, _, err := h.deployGameImpl(gameType, params)
+	h.Require.Errorf(err, "Should not be able to deploy game with depth %v", depth)
+	requireRevertReason(h.Require, err, "InvalidSplitDepth()")
+}
+
+// StartGameWithBadExtraData attempts to create an output alphabet game using the supplied extra data and checks that
+// the creation reverts with BadExtraData().
+func (h *FactoryHelper) StartGameWithBadExtraData(ctx context.Context, rootClaim common.Hash, extraData []byte) {
+	h.T.Logf("Creating game with bad extra data: %x", extraData)
+	opts := *h.Opts
+	opts.Context = ctx
+	_, err := h.Factory.Create(&opts, alphabetGameType, rootClaim, extraData)
+	h.Require.Errorf(err, "Should not be able to create game with extra data %x", extraData)
+	requireRevertReason(h.Require, err, "BadExtraData()")
+}
+
 func (h *FactoryHelper) StartChallenger(ctx context.Context, name string, options ...challenger.Option) *challenger.Helper {
 	opts := []challenger.Option{
 		challenger.WithFactoryAddress(h.FactoryAddr),
@@ -298,3 +638,73 @@ func (h *FactoryHelper) StartChallenger(ctx context.Context, name string, option
 	})
 	return c
 }
+
+// StartChallengerWithDenylist starts a challenger that plays every game created by the factory except those in addrs.
+func (h *FactoryHelper) StartChallengerWithDenylist(ctx context.Context, addrs []common.Address, options ...challenger.Option) *challenger.Helper {
+	var opts []challenger.Option
+	for _, addr := range addrs {
+		opts = append(opts, challenger.WithDeniedGame(addr))
+	}
+	opts = append(opts, options...)
+	return h.StartChallenger(ctx, "Challenger", opts...)
+}
+
+// AssertChallengerSkipsDenied checks that no claims are added to the game at addr while several L1 blocks are
+// produced. It should be called after the challenger has acted on a different game to be sure it is running.
+func (h *FactoryHelper) AssertChallengerSkipsDenied(ctx context.Context, addr common.Address) {
+	game, err := bindings.NewFaultDisputeGame(addr, h.Client)
+	h.Require.NoError(err)
+	for i := 0; i < 5; i++ {
+		h.Require.NoError(wait.ForNextBlock(ctx, h.Client))
+		count, err := game.ClaimDataLen(&bind.CallOpts{Context: ctx})
+		h.Require.NoError(err, "Failed to load claim count")
+		h.Require.EqualValuesf(1, count.Int64(), "Challenger acted on denied game %v", addr)
+	}
+}
+
+// GameCount returns the number of games created by the factory.
+func (h *FactoryHelper) GameCount(ctx context.Context) int64 {
+	count, err := h.Factory.GameCount(&bind.CallOpts{Context: ctx})
+	h.Require.NoError(err, "Failed to get game count")
+	return count.Int64()
+}
+
+// GetGame returns the address and game type of the game at index in the factory's list of created games.
+func (h *FactoryHelper) GetGame(ctx context.Context, index int64) (common.Address, uint32) {
+	game, err := h.Factory.GameAtIndex(&bind.CallOpts{Context: ctx}, big.NewInt(index))
+	h.Require.NoErrorf(err, "Failed to load game at index %v", index)
+	return game.Proxy, game.GameType
+}
+
+// AssertEventCountMatchesGameCount checks that every DisputeGameCreated event emitted from fromBlock onwards has a
+// matching entry, in the same order, in the factory's list of games and that no games were added without an event.
+func (h *FactoryHelper) AssertEventCountMatchesGameCount(ctx context.Context, fromBlock uint64) {
+	head, err := h.Client.BlockNumber(ctx)
+	h.Require.NoError(err, "Failed to get L1 head")
+	gameCountAt := func(blockNum uint64) uint64 {
+		count, err := h.Factory.GameCount(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNum)})
+		h.Require.NoErrorf(err, "Failed to get game count at block %v", blockNum)
+		return count.Uint64()
+	}
+	var startIdx uint64
+	if fromBlock > 0 {
+		startIdx = gameCountAt(fromBlock - 1)
+	}
+	endIdx := gameCountAt(head)
+
+	iter, err := h.Factory.FilterDisputeGameCreated(&bind.FilterOpts{Context: ctx, Start: fromBlock, End: &head}, nil, nil, nil)
+	h.Require.NoError(err, "Failed to filter game created events")
+	defer iter.Close()
+	var events []common.Address
+	for iter.Next() {
+		events = append(events, iter.Event.DisputeProxy)
+	}
+	h.Require.NoError(iter.Error(), "Failed to iterate game created events")
+	h.Require.EqualValuesf(endIdx-startIdx, len(events), "Game created events from block %v do not match game count", fromBlock)
+
+	for i, proxy := range events {
+		game, err := h.Factory.GameAtIndex(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(head)}, new(big.Int).SetUint64(startIdx+uint64(i)))
+		h.Require.NoErrorf(err, "Failed to load game at index %v", startIdx+uint64(i))
+		h.Require.Equalf(proxy, game.Proxy, "Game at index %v does not match created event", startIdx+uint64(i))
+	}
+}
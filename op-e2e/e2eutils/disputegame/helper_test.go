@@ -0,0 +1,35 @@
+package disputegame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckGameStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   Status
+		expected Status
+		done     bool
+		err      string
+	}{
+		{name: "Matches", actual: StatusChallengerWins, expected: StatusChallengerWins, done: true},
+		{name: "MatchesInProgress", actual: StatusInProgress, expected: StatusInProgress, done: true},
+		{name: "StillInProgress", actual: StatusInProgress, expected: StatusDefenderWins},
+		{name: "ResolvedToOther", actual: StatusDefenderWins, expected: StatusChallengerWins, err: "game resolved to Defender Wins, expected Challenger Wins"},
+		{name: "ResolvedWhenInProgressExpected", actual: StatusChallengerWins, expected: StatusInProgress, err: "game resolved to Challenger Wins, expected In Progress"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			done, err := checkGameStatus(test.actual, test.expected)
+			require.Equal(t, test.done, done)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -2,15 +2,33 @@ package disputegame
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/challenger"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
 )
 
 type OutputAlphabetGameHelper struct {
@@ -36,13 +54,54 @@ func (g *OutputAlphabetGameHelper) StartChallenger(
 	return c
 }
 
+// StartChallengerWithWrongPrestate starts a challenger that requires the game's prestates to match its own. The game
+// must have been created by a factory using WithAlphabetAbsolutePrestate so its on-chain absolute prestate differs
+// from the alphabet prestate the challenger expects and the challenger's alphabet prestate validator rejects it.
+func (g *OutputAlphabetGameHelper) StartChallengerWithWrongPrestate(
+	ctx context.Context,
+	l2Node string,
+	name string,
+	options ...challenger.Option,
+) *challenger.Helper {
+	g.Require.ErrorIs(g.alphabetPrestateValidator(ctx).Validate(ctx), gameTypes.ErrInvalidPrestate,
+		"Game must have an absolute prestate that differs from the alphabet prestate")
+	opts := []challenger.Option{challenger.WithValidPrestateRequired()}
+	opts = append(opts, options...)
+	return g.StartChallenger(ctx, l2Node, name, opts...)
+}
+
+// StartChallengerAfterGame starts a challenger for a game that already exists on L1. Before the challenger is started
+// it checks that the game has not yet been responded to and waits for a new L1 block so the game creation is part of
+// the chain history rather than the current head the challenger sees at startup.
+func (g *OutputAlphabetGameHelper) StartChallengerAfterGame(
+	ctx context.Context,
+	l2Node string,
+	name string,
+	options ...challenger.Option,
+) *challenger.Helper {
+	g.Require.EqualValues(1, g.getClaimCount(ctx), "Game should not have any responses before the challenger starts")
+	g.Require.NoError(wait.ForNextBlock(ctx, g.Client))
+	return g.StartChallenger(ctx, l2Node, name, options...)
+}
+
+// AssertChallengerErrorsOnPrestateMismatch checks that the challenger rejects the game because its absolute prestate
+// differs from the alphabet prestate and then leaves the game's claims unchanged. The challenger must have been
+// started for this game by StartChallengerWithWrongPrestate.
+func (g *OutputAlphabetGameHelper) AssertChallengerErrorsOnPrestateMismatch(ctx context.Context, c *challenger.Helper) {
+	expected := g.getClaimCount(ctx)
+	c.WaitForInvalidPrestate(ctx, g.Addr, "alphabet")
+	g.WaitForClaimCountStaysAt(ctx, expected, 10*time.Second)
+}
+
+// alphabetPrestateValidator creates the validator the challenger uses to check the game's alphabet absolute prestate.
+func (g *OutputAlphabetGameHelper) alphabetPrestateValidator(ctx context.Context) *fault.PrestateValidator {
+	contract, _, _ := g.loadContract(ctx)
+	return fault.NewPrestateValidator("alphabet", contract.GetAbsolutePrestateHash, alphabet.PrestateProvider)
+}
+
 func (g *OutputAlphabetGameHelper) CreateHonestActor(ctx context.Context, l2Node string) *OutputHonestHelper {
 	logger := testlog.Logger(g.T, log.LevelInfo).New("role", "HonestHelper", "game", g.Addr)
-	caller := batching.NewMultiCaller(g.System.NodeClient("l1").Client(), batching.DefaultBatchSize)
-	contract, err := contracts.NewFaultDisputeGameContract(ctx, contractMetrics.NoopContractMetrics, g.Addr, caller)
-	g.Require.NoError(err)
-	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
-	g.Require.NoError(err, "Get block range")
+	contract, prestateBlock, poststateBlock := g.loadContract(ctx)
 	splitDepth := g.SplitDepth(ctx)
 	l1Head := g.GetL1Head(ctx)
 	rollupClient := g.System.RollupClient(l2Node)
@@ -52,6 +111,227 @@ func (g *OutputAlphabetGameHelper) CreateHonestActor(ctx context.Context, l2Node
 	return NewOutputHonestHelper(g.T, g.Require, &g.OutputGameHelper, contract, correctTrace)
 }
 
+// CreateDishonestAlphabetActor creates an actor whose bottom game trace has the wrong letter at corruptIndex.
+func (g *OutputAlphabetGameHelper) CreateDishonestAlphabetActor(ctx context.Context, corruptIndex uint64) *OutputHonestHelper {
+	contract, _, _ := g.loadContract(ctx)
+	letters, err := alphabetLetters(ctx, g.MaxDepth(ctx)-g.SplitDepth(ctx)-1)
+	g.Require.NoError(err, "Failed to evaluate alphabet trace")
+	g.Require.Less(corruptIndex, uint64(len(letters)), "Corrupt index is beyond the end of the trace")
+	letters[corruptIndex]++
+	return NewOutputHonestHelper(g.T, g.Require, &g.OutputGameHelper, contract, g.claimedAlphabetAccessor(ctx, string(letters)))
+}
+
+// loadContract creates a contract binding for the game and loads the L2 block range it disputes.
+func (g *OutputAlphabetGameHelper) loadContract(ctx context.Context) (contracts.FaultDisputeGameContract, uint64, uint64) {
+	caller := batching.NewMultiCaller(g.System.NodeClient("l1").Client(), batching.DefaultBatchSize)
+	contract, err := contracts.NewFaultDisputeGameContract(ctx, contractMetrics.NoopContractMetrics, g.Addr, caller)
+	g.Require.NoError(err)
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+	g.Require.NoError(err, "Get block range")
+	return contract, prestateBlock, poststateBlock
+}
+
 func (g *OutputAlphabetGameHelper) CreateDishonestHelper(ctx context.Context, l2Node string, defender bool) *DishonestHelper {
 	return newDishonestHelper(&g.OutputGameHelper, g.CreateHonestActor(ctx, l2Node), defender)
 }
+
+// PlayMixedHonest responds to every claim made by other actors in the game, agreeing with some claims and disputing
+// others. agreeAtDepth[d] reports whether the correct value is posted at depth d, otherwise an incorrect value is
+// posted. Depths beyond the end of agreeAtDepth always receive incorrect values, except the first claim of the bottom
+// game which must have a valid status byte and so is always correct.
+// Returns once no new claims have been made for 30 seconds.
+func (g *OutputAlphabetGameHelper) PlayMixedHonest(ctx context.Context, l2Node string, agreeAtDepth []bool) {
+	honest := g.CreateHonestActor(ctx, l2Node)
+	maxDepth := g.MaxDepth(ctx)
+	splitDepth := g.SplitDepth(ctx)
+
+	var numClaimsSeen int64
+	for {
+		// Use a short timeout since the challenger won't respond to claims it agrees with.
+		newCount, err := g.waitForNewClaim(ctx, numClaimsSeen, 30*time.Second)
+		if errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		g.Require.NoError(err)
+
+		for ; numClaimsSeen < newCount; numClaimsSeen++ {
+			claimData := g.getClaim(ctx, numClaimsSeen)
+			if claimData.Claimant == g.Opts.From {
+				continue
+			}
+			pos := types.NewPositionFromGIndex(claimData.Position)
+			if pos.Depth() == maxDepth {
+				continue
+			}
+			moveDepth := pos.Depth() + 1
+			if moveDepth == splitDepth+1 || (int(moveDepth) < len(agreeAtDepth) && agreeAtDepth[moveDepth]) {
+				honest.Attack(ctx, numClaimsSeen, WithIgnoreDuplicates())
+			} else {
+				g.Attack(ctx, numClaimsSeen, common.Hash{0xee, byte(numClaimsSeen)}, WithIgnoreDuplicates())
+			}
+		}
+	}
+	g.LogGameData(ctx)
+}
+
+// AttackWithAlphabet attacks the output root leaf claim with the root claim of a bottom game whose execution trace is
+// claimedAlphabet. Returns the bottom game root claim.
+func (g *OutputAlphabetGameHelper) AttackWithAlphabet(ctx context.Context, claim *ClaimHelper, claimedAlphabet string) *ClaimHelper {
+	g.Require.True(claim.IsOutputRootLeaf(ctx), "Bottom game must be started from an output root leaf")
+	game := g.gameState(ctx)
+	target := game.Claims()[claim.Index]
+	value, err := g.claimedAlphabetAccessor(ctx, claimedAlphabet).Get(ctx, game, target, target.Position.Attack())
+	g.Require.NoErrorf(err, "Failed to evaluate claimed alphabet %q", claimedAlphabet)
+	return claim.Attack(ctx, value)
+}
+
+// AssertResolutionMatchesTrace waits for the game to be resolved and checks that the winner matches the outcome
+// predicted by evaluating claimedAlphabet off-chain. The game must have a single bottom game, started with
+// AttackWithAlphabet below a top game that is a single chain of claims, such as one created by DisputeLastBlock.
+// Every claim posted in the bottom game by the team of its root claim must commit to claimedAlphabet, which is compared
+// with the correct alphabet trace for the bottom game's prestate. honest indicates whether an honest challenger plays
+// the game: the bottom game root claim is only expected to be defeated if claimedAlphabet is incorrect and an honest
+// challenger counters it.
+func (g *OutputAlphabetGameHelper) AssertResolutionMatchesTrace(ctx context.Context, claimedAlphabet string, honest bool) {
+	actual := g.WaitForResolved(ctx)
+	g.Require.NoError(g.alphabetPrestateValidator(ctx).Validate(ctx), "Game must use the alphabet absolute prestate")
+
+	game := g.gameState(ctx)
+	splitDepth := g.SplitDepth(ctx)
+	var topClaims int
+	var bottomRoot *types.Claim
+	for _, claim := range game.Claims() {
+		if claim.Depth() <= splitDepth {
+			topClaims++
+		} else if claim.Depth() == splitDepth+1 {
+			g.Require.Nilf(bottomRoot, "Game must have a single bottom game\n%v", g.GameData(ctx))
+			root := claim
+			bottomRoot = &root
+		}
+	}
+	g.Require.Equalf(int(splitDepth)+1, topClaims, "Top game must be a single chain of claims\n%v", g.GameData(ctx))
+	g.Require.NotNilf(bottomRoot, "Game must have a bottom game\n%v", g.GameData(ctx))
+
+	claimedTrace := g.claimedAlphabetAccessor(ctx, claimedAlphabet)
+	for _, claim := range game.Claims() {
+		if claim.Depth() <= splitDepth || claim.Depth()%2 != bottomRoot.Depth()%2 {
+			continue
+		}
+		expected, err := claimedTrace.Get(ctx, game, claim, claim.Position)
+		g.Require.NoErrorf(err, "Failed to evaluate claimed alphabet %q for claim %v", claimedAlphabet, claim.ContractIndex)
+		g.Require.Equalf(expected, claim.Value, "Claim %v does not commit to claimed alphabet %q", claim.ContractIndex, claimedAlphabet)
+	}
+
+	bottomRootStands := isCorrectAlphabet(ctx, g.Require, claimedAlphabet) || !honest
+	// The top game is a single chain of claims, so the team of the bottom game root claim wins the game only if the
+	// bottom game root claim stands. Claims at odd depths are made by the challenger team.
+	challengerTeam := bottomRoot.Depth()%2 == 1
+	expected := StatusDefenderWins
+	if bottomRootStands == challengerTeam {
+		expected = StatusChallengerWins
+	}
+	g.Require.Equalf(expected, actual, "Game resolution did not match off-chain evaluation of %q. Game state: \n%v", claimedAlphabet, g.GameData(ctx))
+}
+
+// gameState loads the current claims of the game.
+func (g *OutputAlphabetGameHelper) gameState(ctx context.Context) types.Game {
+	contract, _, _ := g.loadContract(ctx)
+	claims, err := contract.GetAllClaims(ctx, rpcblock.Latest)
+	g.Require.NoError(err, "Failed to load claims from game")
+	return types.NewGameState(claims, g.MaxDepth(ctx))
+}
+
+// claimedAlphabetAccessor creates a trace accessor that uses the correct output roots and claimedAlphabet as the trace
+// of every bottom game.
+func (g *OutputAlphabetGameHelper) claimedAlphabetAccessor(ctx context.Context, claimedAlphabet string) types.TraceAccessor {
+	outputProvider := g.CorrectOutputProvider
+	creator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		return newClaimedAlphabetProvider(ctx, agreed.L2BlockNumber, depth, claimedAlphabet)
+	}
+	selector := split.NewSplitProviderSelector(outputProvider, g.SplitDepth(ctx), outputs.OutputRootSplitAdapter(outputProvider, creator))
+	return trace.NewAccessor(selector)
+}
+
+// isCorrectAlphabet evaluates the alphabet trace off-chain and reports whether every letter of claimedAlphabet matches
+// it. claimedAlphabet must have a power of two length.
+func isCorrectAlphabet(ctx context.Context, require *require.Assertions, claimedAlphabet string) bool {
+	depth := types.Depth(bits.Len(uint(len(claimedAlphabet))) - 1)
+	require.Equalf(1<<depth, len(claimedAlphabet), "Claimed alphabet %q length must be a power of two", claimedAlphabet)
+	letters, err := alphabetLetters(ctx, depth)
+	require.NoError(err, "Failed to evaluate alphabet trace")
+	return string(letters) == claimedAlphabet
+}
+
+// alphabetLetters returns the letters of the correct alphabet trace with the given depth starting at block 0.
+func alphabetLetters(ctx context.Context, depth types.Depth) ([]byte, error) {
+	correctTrace := alphabet.NewTraceProvider(big.NewInt(0), depth)
+	letters := make([]byte, 1<<depth)
+	for i := range letters {
+		// Step data returns the pre-state, so use the next index to get the state committed to at index i
+		state, _, _, err := correctTrace.GetStepData(ctx, types.NewPosition(depth, big.NewInt(int64(i+1))))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate alphabet trace at index %v: %w", i, err)
+		}
+		letters[i] = state[len(state)-1]
+	}
+	return letters, nil
+}
+
+// claimedAlphabetProvider is an alphabet trace provider with the letters of a claimed alphabet, given as for block 0.
+type claimedAlphabetProvider struct {
+	*alphabet.AlphabetTraceProvider
+	depth   types.Depth
+	offsets []byte
+}
+
+func newClaimedAlphabetProvider(ctx context.Context, startingBlockNumber *big.Int, depth types.Depth, claimedAlphabet string) (*claimedAlphabetProvider, error) {
+	if len(claimedAlphabet) != 1<<depth {
+		return nil, fmt.Errorf("claimed alphabet %q does not have length %v", claimedAlphabet, 1<<depth)
+	}
+	letters, err := alphabetLetters(ctx, depth)
+	if err != nil {
+		return nil, err
+	}
+	offsets := make([]byte, len(claimedAlphabet))
+	for i := range claimedAlphabet {
+		offsets[i] = claimedAlphabet[i] - letters[i]
+	}
+	return &claimedAlphabetProvider{
+		AlphabetTraceProvider: alphabet.NewTraceProvider(startingBlockNumber, depth),
+		depth:                 depth,
+		offsets:               offsets,
+	}, nil
+}
+
+func (p *claimedAlphabetProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	prestate, proof, preimageData, err := p.AlphabetTraceProvider.GetStepData(ctx, pos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// The pre-state at trace index i is the state after letter i-1. Index 0 is the absolute prestate.
+	if traceIndex := pos.TraceIndex(p.depth).Uint64(); traceIndex > 0 {
+		prestate = slices.Clone(prestate)
+		prestate[len(prestate)-1] += p.offsets[traceIndex-1]
+	}
+	return prestate, proof, preimageData, nil
+}
+
+func (p *claimedAlphabetProvider) Get(ctx context.Context, i types.Position) (common.Hash, error) {
+	if i.Depth() > p.depth {
+		return p.AlphabetTraceProvider.Get(ctx, i)
+	}
+	// Step data returns the pre-state, so add 1 to get the state for index i
+	postPosition := types.NewPosition(p.depth, new(big.Int).Add(i.TraceIndex(p.depth), big.NewInt(1)))
+	state, _, _, err := p.GetStepData(ctx, postPosition)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return alphabetStateHash(state), nil
+}
+
+// alphabetStateHash returns the claim committing to an alphabet VM state.
+func alphabetStateHash(state []byte) common.Hash {
+	hash := crypto.Keccak256Hash(state)
+	hash[0] = mipsevm.VMStatusInvalid
+	return hash
+}
@@ -0,0 +1,70 @@
+package disputegame
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimedAlphabetProvider(t *testing.T) {
+	depth := types.Depth(3)
+	startingBlock := big.NewInt(2)
+	honest := alphabet.NewTraceProvider(startingBlock, depth)
+
+	t.Run("CorrectAlphabet", func(t *testing.T) {
+		claimed, err := newClaimedAlphabetProvider(context.Background(), startingBlock, depth, "abcdefgh")
+		require.NoError(t, err)
+		for i := int64(0); i < 1<<depth; i++ {
+			pos := types.NewPosition(depth, big.NewInt(i))
+			expected, err := honest.Get(context.Background(), pos)
+			require.NoError(t, err)
+			actual, err := claimed.Get(context.Background(), pos)
+			require.NoError(t, err)
+			require.Equalf(t, expected, actual, "Claim at index %v should match the correct trace", i)
+		}
+	})
+
+	t.Run("IncorrectLetter", func(t *testing.T) {
+		claimed, err := newClaimedAlphabetProvider(context.Background(), startingBlock, depth, "abcdexgh")
+		require.NoError(t, err)
+		for i := int64(0); i < 1<<depth; i++ {
+			pos := types.NewPosition(depth, big.NewInt(i))
+			expected, err := honest.Get(context.Background(), pos)
+			require.NoError(t, err)
+			actual, err := claimed.Get(context.Background(), pos)
+			require.NoError(t, err)
+			if i == 5 {
+				require.NotEqual(t, expected, actual, "Claim at incorrect letter should differ")
+			} else {
+				require.Equalf(t, expected, actual, "Claim at index %v should match the correct trace", i)
+			}
+		}
+	})
+
+	t.Run("StepDataMatchesClaims", func(t *testing.T) {
+		claimed, err := newClaimedAlphabetProvider(context.Background(), startingBlock, depth, "abcdexgh")
+		require.NoError(t, err)
+		// The pre-state of the step after the incorrect letter must match the claim it commits to
+		prestate, _, _, err := claimed.GetStepData(context.Background(), types.NewPosition(depth, big.NewInt(6)))
+		require.NoError(t, err)
+		expected, err := claimed.Get(context.Background(), types.NewPosition(depth, big.NewInt(5)))
+		require.NoError(t, err)
+		require.Equal(t, expected, alphabetStateHash(prestate))
+
+		// The absolute prestate is unchanged
+		prestate, _, _, err = claimed.GetStepData(context.Background(), types.NewPosition(depth, big.NewInt(0)))
+		require.NoError(t, err)
+		honestPrestate, _, _, err := honest.GetStepData(context.Background(), types.NewPosition(depth, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, honestPrestate, prestate)
+	})
+
+	t.Run("WrongLength", func(t *testing.T) {
+		_, err := newClaimedAlphabetProvider(context.Background(), startingBlock, depth, "abcd")
+		require.Error(t, err)
+	})
+}
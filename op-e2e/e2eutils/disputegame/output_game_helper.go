@@ -3,8 +3,11 @@ package disputegame
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"slices"
 	"testing"
 	"time"
 
@@ -15,15 +18,21 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +48,8 @@ type OutputGameHelper struct {
 	Addr                  common.Address
 	CorrectOutputProvider *outputs.OutputTraceProvider
 	System                DisputeSystem
+	// Metrics records the gas used by moves made with Attack, Defend and Step.
+	Metrics metrics.MoveMetricer
 }
 
 func NewOutputGameHelper(t *testing.T, require *require.Assertions, client *ethclient.Client, opts *bind.TransactOpts,
@@ -53,6 +64,7 @@ func NewOutputGameHelper(t *testing.T, require *require.Assertions, client *ethc
 		Addr:                  addr,
 		CorrectOutputProvider: correctOutputProvider,
 		System:                system,
+		Metrics:               &metrics.NoopMoveMetrics{},
 	}
 }
 
@@ -178,6 +190,32 @@ func (g *OutputGameHelper) MaxClockDuration(ctx context.Context) time.Duration {
 	return time.Duration(duration) * time.Second
 }
 
+// WaitForResolvable waits until the challenger clock of every claim in the game has expired, at which point all
+// subgames and then the game itself can be resolved without reverting.
+func (g *OutputGameHelper) WaitForResolvable(ctx context.Context) {
+	maxClockDuration := g.MaxClockDuration(ctx)
+	timedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		count, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: timedCtx})
+		if err != nil {
+			return false, fmt.Errorf("retrieve number of claims: %w", err)
+		}
+		for i := int64(0); i < count.Int64(); i++ {
+			duration, err := g.Game.GetChallengerDuration(&bind.CallOpts{Context: timedCtx}, big.NewInt(i))
+			if err != nil {
+				return false, fmt.Errorf("retrieve challenger duration of claim %v: %w", i, err)
+			}
+			if time.Duration(duration)*time.Second < maxClockDuration {
+				g.T.Logf("Waiting for clock of claim %v to expire, %vs of %v elapsed", i, duration, maxClockDuration)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	g.Require.NoErrorf(err, "Game %v did not become resolvable", g.Addr)
+}
+
 func (g *OutputGameHelper) WaitForNoAvailableCredit(ctx context.Context, addr common.Address) {
 	timedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
@@ -228,8 +266,15 @@ func (g *OutputGameHelper) WethBalance(ctx context.Context, addr common.Address)
 func (g *OutputGameHelper) WaitForClaimCount(ctx context.Context, count int64) {
 	timedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
-	err := wait.For(timedCtx, time.Second, func() (bool, error) {
-		actual, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: timedCtx})
+	g.WaitForClaimCountCtx(timedCtx, count)
+}
+
+// WaitForClaimCountCtx waits until there are at least count claims in the game, in the same way as
+// WaitForClaimCount, but without applying the default timeout. The caller is responsible for setting a deadline on
+// ctx.
+func (g *OutputGameHelper) WaitForClaimCountCtx(ctx context.Context, count int64) {
+	err := wait.For(ctx, time.Second, func() (bool, error) {
+		actual, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: ctx})
 		if err != nil {
 			return false, err
 		}
@@ -237,7 +282,10 @@ func (g *OutputGameHelper) WaitForClaimCount(ctx context.Context, count int64) {
 		return actual.Cmp(big.NewInt(count)) >= 0, nil
 	})
 	if err != nil {
-		g.LogGameData(ctx)
+		// ctx has likely expired so use a fresh context to log the game data.
+		logCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		g.LogGameData(logCtx)
 		g.Require.NoErrorf(err, "Did not find expected claim count %v", count)
 	}
 }
@@ -258,6 +306,35 @@ func (g *OutputGameHelper) MaxDepth(ctx context.Context) types.Depth {
 	return types.Depth(depth.Uint64())
 }
 
+// WaitForClaim waits for a claim with a value matching predicate and returns the index of the first matching claim.
+// Fails the test, logging all claims in the game, if no matching claim is posted before the default timeout.
+func (g *OutputGameHelper) WaitForClaim(ctx context.Context, predicate func(index int64, claim common.Hash) bool) int64 {
+	timedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	var matchIdx int64
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		count, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: timedCtx})
+		if err != nil {
+			return false, fmt.Errorf("retrieve number of claims: %w", err)
+		}
+		for i := int64(0); i < count.Int64(); i++ {
+			claimData, err := g.Game.ClaimData(&bind.CallOpts{Context: timedCtx}, big.NewInt(i))
+			if err != nil {
+				return false, fmt.Errorf("retrieve claim %v: %w", i, err)
+			}
+			if predicate(i, claimData.Claim) {
+				matchIdx = i
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		g.Require.NoErrorf(err, "Did not find matching claim in game %v\n%v", g.Addr, g.GameData(ctx))
+	}
+	return matchIdx
+}
+
 func (g *OutputGameHelper) waitForClaim(ctx context.Context, timeout time.Duration, errorMsg string, predicate func(claimIdx int64, claim ContractClaim) bool) (int64, ContractClaim) {
 	timedCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -313,12 +390,25 @@ func (g *OutputGameHelper) waitForNoClaim(ctx context.Context, errorMsg string,
 	}
 }
 
+// GetClaimValue returns the value of the claim at claimIdx. It does not wait for the claim to be posted, failing the
+// test immediately if claimIdx is beyond the current claim count. Use WaitForClaimCount first to wait for new claims.
 func (g *OutputGameHelper) GetClaimValue(ctx context.Context, claimIdx int64) common.Hash {
-	g.WaitForClaimCount(ctx, claimIdx+1)
+	g.requireClaimExists(ctx, claimIdx)
 	claim := g.getClaim(ctx, claimIdx)
 	return claim.Claim
 }
 
+// GetClaimByPosition returns the index and value of the claim at position, specified as a generalized index.
+// The final return value is false if there is no claim at that position.
+func (g *OutputGameHelper) GetClaimByPosition(ctx context.Context, position *big.Int) (int64, common.Hash, bool) {
+	for i, claim := range g.getAllClaims(ctx) {
+		if claim.Position.Cmp(position) == 0 {
+			return int64(i), claim.Claim, true
+		}
+	}
+	return 0, common.Hash{}, false
+}
+
 func (g *OutputGameHelper) getAllClaims(ctx context.Context) []ContractClaim {
 	count := g.getClaimCount(ctx)
 	var claims []ContractClaim
@@ -370,6 +460,8 @@ func (g *OutputGameHelper) WaitForAllClaimsCountered(ctx context.Context) {
 		})
 }
 
+// Resolve resolves the game itself. Every claim must already have been resolved with ResolveClaim, working from the
+// deepest claims up to the root claim, so this is the final step of resolution.
 func (g *OutputGameHelper) Resolve(ctx context.Context) {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
@@ -397,11 +489,61 @@ func (g *OutputGameHelper) WaitForGameStatus(ctx context.Context, expected Statu
 			return false, fmt.Errorf("game status unavailable: %w", err)
 		}
 		g.T.Logf("Game %v has state %v, waiting for state %v", g.Addr, Status(status), expected)
-		return expected == Status(status), nil
+		return checkGameStatus(Status(status), expected)
 	})
 	g.Require.NoErrorf(err, "wait for Game status. Game state: \n%v", g.GameData(ctx))
 }
 
+// WaitForResolved waits for the game to be resolved and returns its final status. Unlike WaitForGameStatus, the
+// caller doesn't need to know the expected outcome so this can be used to report why a game ended unexpectedly.
+// Fails the test, logging the game data, only if the game is not resolved before the default timeout.
+func (g *OutputGameHelper) WaitForResolved(ctx context.Context) Status {
+	g.T.Logf("Waiting for game %v to be resolved", g.Addr)
+	timedCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	var status Status
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(timedCtx, 30*time.Second)
+		defer cancel()
+		s, err := g.Game.Status(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return false, fmt.Errorf("game status unavailable: %w", err)
+		}
+		status = Status(s)
+		return status != StatusInProgress, nil
+	})
+	g.Require.NoErrorf(err, "wait for game to resolve. Game state: \n%v", g.GameData(ctx))
+	g.T.Logf("Game %v resolved with status %v", g.Addr, status)
+	return status
+}
+
+// AssertHonestWins waits for the game to be resolved and checks that the side that agrees with the correct output
+// root won, regardless of which intermediate claims were agreed with. A valid root claim should result in the defender
+// winning and an invalid one in the challenger winning.
+func (g *OutputGameHelper) AssertHonestWins(ctx context.Context) {
+	expected := StatusChallengerWins
+	if g.GetClaimValue(ctx, 0) == g.correctOutputRoot(ctx, types.NewPositionFromGIndex(big.NewInt(1))) {
+		expected = StatusDefenderWins
+	}
+	actual := g.WaitForResolved(ctx)
+	g.Require.Equalf(expected, actual, "Honest side did not win. Game state: \n%v", g.GameData(ctx))
+}
+
+// AssertGameStillResolves advances time until all clocks have expired and checks the game is resolved in favour of
+// the honest side, as determined by AssertHonestWins.
+func (g *OutputGameHelper) AssertGameStillResolves(ctx context.Context) {
+	g.System.AdvanceTime(g.MaxClockDuration(ctx))
+	g.Require.NoError(wait.ForNextBlock(ctx, g.Client))
+	g.AssertHonestWins(ctx)
+}
+
+// AssertChallengerCatchesUp checks that a challenger started after the game was created discovers it and counters
+// the root claim, then advances time until all clocks have expired and checks the game resolves as expected.
+func (g *OutputGameHelper) AssertChallengerCatchesUp(ctx context.Context) {
+	g.RootClaim(ctx).WaitForCounterClaim(ctx)
+	g.AssertGameStillResolves(ctx)
+}
+
 func (g *OutputGameHelper) WaitForInactivity(ctx context.Context, numInactiveBlocks int, untilGameEnds bool) {
 	g.T.Logf("Waiting for game %v to have no activity for %v blocks", g.Addr, numInactiveBlocks)
 	headCh := make(chan *gethtypes.Header, 100)
@@ -442,6 +584,344 @@ func (g *OutputGameHelper) WaitForInactivity(ctx context.Context, numInactiveBlo
 	}
 }
 
+// WaitForClaimCountStable polls the number of claims in the game every pollInterval and returns once the count has
+// not changed for stableFor. Unlike WaitForInactivity, which counts L1 blocks without transactions to the game, this
+// is based on wall-clock time. Fails the test if ctx is done before the claim count stabilises.
+func (g *OutputGameHelper) WaitForClaimCountStable(ctx context.Context, pollInterval time.Duration, stableFor time.Duration) {
+	g.T.Logf("Waiting for game %v to have no new claims for %v", g.Addr, stableFor)
+	lastCount := g.getClaimCount(ctx)
+	lastChange := time.Now()
+	err := wait.For(ctx, pollInterval, func() (bool, error) {
+		count, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return false, fmt.Errorf("retrieve number of claims: %w", err)
+		}
+		if count.Int64() != lastCount {
+			g.T.Logf("Game %v claim count changed from %v to %v", g.Addr, lastCount, count)
+			lastCount = count.Int64()
+			lastChange = time.Now()
+			return false, nil
+		}
+		return time.Since(lastChange) >= stableFor, nil
+	})
+	g.Require.NoErrorf(err, "Claim count of game %v did not stabilise", g.Addr)
+}
+
+// WaitForClaimCountStaysAt checks that the game has exactly count claims for the duration d, polling every second.
+// Fails the test as soon as the claim count differs from count, including if it differs when first checked.
+// Intended for asserting that a challenger does not act on a game without relying on sleeps.
+func (g *OutputGameHelper) WaitForClaimCountStaysAt(ctx context.Context, count int64, d time.Duration) {
+	g.T.Logf("Checking game %v keeps %v claims for %v", g.Addr, count, d)
+	end := time.Now().Add(d)
+	err := wait.For(ctx, time.Second, func() (bool, error) {
+		actual, err := g.Game.ClaimDataLen(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return false, fmt.Errorf("retrieve number of claims: %w", err)
+		}
+		if actual.Int64() != count {
+			return false, fmt.Errorf("claim count changed to %v, expected %v", actual, count)
+		}
+		return !time.Now().Before(end), nil
+	})
+	g.Require.NoErrorf(err, "Claim count of game %v did not stay at %v\n%v", g.Addr, count, g.GameData(ctx))
+}
+
+// AssertNoDuplicateMovesAfterRestarts checks that no claimant has posted more than one counter to the same parent
+// claim. An honest challenger only ever makes a single move in response to a claim so multiple responses indicate
+// that a move was replayed or a conflicting move was made after a restart.
+func (g *OutputGameHelper) AssertNoDuplicateMovesAfterRestarts(ctx context.Context) {
+	type response struct {
+		parentIdx uint32
+		claimant  common.Address
+	}
+	responses := make(map[response]int64)
+	for i, claim := range g.getAllClaims(ctx) {
+		if i == 0 {
+			// Root claim has no parent
+			continue
+		}
+		key := response{parentIdx: claim.ParentIndex, claimant: claim.Claimant}
+		if prevIdx, ok := responses[key]; ok {
+			g.Require.Failf("Duplicate move", "Claimant %v responded to claim %v with both claim %v and claim %v. Game state: \n%v",
+				claim.Claimant, claim.ParentIndex, prevIdx, i, g.GameData(ctx))
+		}
+		responses[key] = int64(i)
+	}
+}
+
+// AssertMovesCausallyOrdered checks that every claim in the game was posted after its parent claim was visible on
+// chain. Claims responding to a claim made by a different claimant must be included in a later block than their
+// parent, since the claimant couldn't have seen the parent before it was included.
+func (g *OutputGameHelper) AssertMovesCausallyOrdered(ctx context.Context) {
+	type postedAt struct {
+		block    uint64
+		logIdx   uint
+		claimant common.Address
+	}
+	rootClaim := g.getClaim(ctx, 0)
+	// The root claim is created when the game is initialized so treat it as posted before any moves.
+	posted := []postedAt{{claimant: rootClaim.Claimant}}
+
+	iter, err := g.Game.FilterMove(&bind.FilterOpts{Context: ctx}, nil, nil, nil)
+	g.Require.NoError(err, "Failed to filter move events")
+	defer iter.Close()
+	for iter.Next() {
+		move := iter.Event
+		claimIdx := len(posted)
+		parentIdx := move.ParentIndex.Uint64()
+		g.Require.Lessf(parentIdx, uint64(claimIdx), "Claim %v has parent %v which does not exist yet", claimIdx, parentIdx)
+		parent := posted[parentIdx]
+		child := postedAt{block: move.Raw.BlockNumber, logIdx: move.Raw.Index, claimant: move.Claimant}
+		if parent.claimant != child.claimant {
+			g.Require.Lessf(parent.block, child.block,
+				"Claim %v posted in block %v before its parent %v from another claimant was visible (block %v)",
+				claimIdx, child.block, parentIdx, parent.block)
+		} else {
+			g.Require.Truef(parent.block < child.block || (parent.block == child.block && parent.logIdx < child.logIdx),
+				"Claim %v posted before its parent %v", claimIdx, parentIdx)
+		}
+		posted = append(posted, child)
+	}
+	g.Require.NoError(iter.Error(), "Failed to iterate move events")
+	g.Require.EqualValues(g.getClaimCount(ctx), len(posted), "Move events do not match claim count")
+}
+
+// AssertValidClaimTree checks the structure of the claim tree. The root claim must be at the root position and every
+// other claim must reference an earlier claim as its parent and be positioned at either the attack or defend position
+// of that parent, without exceeding the max game depth. Claims must also be unique.
+func (g *OutputGameHelper) AssertValidClaimTree(ctx context.Context) {
+	maxDepth := g.MaxDepth(ctx)
+	claims := g.getAllClaims(ctx)
+	g.Require.NotEmpty(claims, "Game has no root claim")
+
+	root := types.NewPositionFromGIndex(claims[0].Position)
+	g.Require.Truef(root.IsRootPosition(), "Root claim is not at the root position: %v", root)
+	g.Require.Equal(uint32(math.MaxUint32), claims[0].ParentIndex, "Root claim should not have a parent")
+
+	type claimID struct {
+		parentIdx uint32
+		gindex    string
+		value     common.Hash
+	}
+	seen := make(map[claimID]int)
+	for i := 1; i < len(claims); i++ {
+		claim := claims[i]
+		g.Require.Lessf(int(claim.ParentIndex), i, "Claim %v references parent %v which is not an earlier claim", i, claim.ParentIndex)
+		parent := claims[claim.ParentIndex]
+		parentPos := types.NewPositionFromGIndex(parent.Position)
+		pos := types.NewPositionFromGIndex(claim.Position)
+		g.Require.LessOrEqualf(pos.Depth(), maxDepth, "Claim %v exceeds max game depth", i)
+		isAttack := pos.ToGIndex().Cmp(parentPos.Attack().ToGIndex()) == 0
+		isDefend := !parentPos.IsRootPosition() && pos.ToGIndex().Cmp(parentPos.Defend().ToGIndex()) == 0
+		g.Require.Truef(isAttack || isDefend, "Claim %v at position %v is not a valid move against parent %v at position %v. Game state: \n%v",
+			i, pos, claim.ParentIndex, parentPos, g.GameData(ctx))
+
+		id := claimID{parentIdx: claim.ParentIndex, gindex: claim.Position.String(), value: common.Hash(claim.Claim)}
+		if prev, ok := seen[id]; ok {
+			g.Require.Failf("Duplicate claim", "Claims %v and %v are identical", prev, i)
+		}
+		seen[id] = i
+	}
+}
+
+// gasBurnerInitCode is contract creation code that loops forever, consuming all gas provided to it.
+var gasBurnerInitCode = []byte{byte(vm.JUMPDEST), byte(vm.PUSH1), 0x00, byte(vm.JUMP)}
+
+// RaiseL1BaseFee fills numBlocks consecutive L1 blocks with a transaction that consumes the full block gas limit.
+// Each full block increases the base fee by 12.5%. Returns the header of the latest block once complete.
+func (g *OutputGameHelper) RaiseL1BaseFee(ctx context.Context, numBlocks int) *gethtypes.Header {
+	chainID, err := g.Client.ChainID(ctx)
+	g.Require.NoError(err, "Failed to get chain ID")
+	tip := big.NewInt(params.GWei)
+	for i := 0; i < numBlocks; i++ {
+		head, err := g.Client.HeaderByNumber(ctx, nil)
+		g.Require.NoError(err, "Failed to get L1 head")
+		nonce, err := g.Client.PendingNonceAt(ctx, g.Opts.From)
+		g.Require.NoError(err, "Failed to get nonce")
+		tx := gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: tip,
+			GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip),
+			Gas:       head.GasLimit,
+			Data:      gasBurnerInitCode,
+		})
+		signed, err := g.Opts.Signer(g.Opts.From, tx)
+		g.Require.NoError(err, "Failed to sign gas burning transaction")
+		g.Require.NoError(g.Client.SendTransaction(ctx, signed), "Failed to send gas burning transaction")
+		// The transaction always runs out of gas
+		_, err = wait.ForReceiptFail(ctx, g.Client, signed.Hash())
+		g.Require.NoError(err, "Gas burning transaction was not included")
+	}
+	head, err := g.Client.HeaderByNumber(ctx, nil)
+	g.Require.NoError(err, "Failed to get L1 head")
+	g.T.Logf("Raised L1 base fee to %v", head.BaseFee)
+	return head
+}
+
+// AssertChallengerLandsMovesUnderHighGas waits for the challenger to counter the latest claim in the game after the
+// base fee was raised to that of the raised block. The counter must pay at least the lowest base fee possible in its
+// inclusion block given the raised base fee, and must land before the clock of the countered claim expires.
+func (g *OutputGameHelper) AssertChallengerLandsMovesUnderHighGas(ctx context.Context, raised *gethtypes.Header) {
+	latestIdx := g.getClaimCount(ctx) - 1
+	counterIdx, counter := g.waitForClaim(ctx, defaultTimeout, fmt.Sprintf("challenger did not counter claim %v", latestIdx),
+		func(claimIdx int64, claim ContractClaim) bool {
+			return int64(claim.ParentIndex) == latestIdx
+		})
+	g.T.Logf("Claim %v was countered by claim %v from %v", latestIdx, counterIdx, counter.Claimant)
+
+	counterEvent := g.moveEvent(ctx, counterIdx)
+	receipt, err := g.Client.TransactionReceipt(ctx, counterEvent.Raw.TxHash)
+	g.Require.NoError(err, "Failed to load counter claim receipt")
+	g.Require.GreaterOrEqual(receipt.BlockNumber.Uint64(), raised.Number.Uint64(), "Counter claim landed before the base fee was raised")
+	// The base fee falls by at most 12.5% per block, so this is the lowest it can be in the inclusion block.
+	minBaseFee := new(big.Int).Set(raised.BaseFee)
+	for n := raised.Number.Uint64(); n < receipt.BlockNumber.Uint64(); n++ {
+		minBaseFee.Mul(minBaseFee, big.NewInt(7))
+		minBaseFee.Div(minBaseFee, big.NewInt(8))
+	}
+	g.Require.GreaterOrEqualf(receipt.EffectiveGasPrice.Cmp(minBaseFee), 0,
+		"Counter claim paid %v per gas, below the minimum base fee %v", receipt.EffectiveGasPrice, minBaseFee)
+
+	counterHeader, err := g.Client.HeaderByHash(ctx, counterEvent.Raw.BlockHash)
+	g.Require.NoError(err, "Failed to load counter claim block")
+	landingTime := time.Duration(counterHeader.Time-g.claimBlockTime(ctx, latestIdx)) * time.Second
+	g.Require.Lessf(landingTime, g.MaxClockDuration(ctx), "Counter claim landed %v after claim %v", landingTime, latestIdx)
+	g.T.Logf("Counter claim landed %v after claim %v, paying %v per gas", landingTime, latestIdx, receipt.EffectiveGasPrice)
+}
+
+// claimBlockTime returns the timestamp of the L1 block that included claimIndex. The root claim is included in the
+// block that created the game.
+func (g *OutputGameHelper) claimBlockTime(ctx context.Context, claimIndex int64) uint64 {
+	var header *gethtypes.Header
+	var err error
+	if claimIndex == 0 {
+		header, err = g.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(g.creationBlock(ctx)))
+	} else {
+		header, err = g.Client.HeaderByHash(ctx, g.moveEvent(ctx, claimIndex).Raw.BlockHash)
+	}
+	g.Require.NoErrorf(err, "Failed to load block for claim %v", claimIndex)
+	return header.Time
+}
+
+// moveEvent returns the Move event emitted when claimIndex was created. The root claim is created with the game rather
+// than by a move so claimIndex must be positive.
+func (g *OutputGameHelper) moveEvent(ctx context.Context, claimIndex int64) *bindings.FaultDisputeGameMove {
+	g.Require.Positive(claimIndex, "Root claim is not created by a move")
+	iter, err := g.Game.FilterMove(&bind.FilterOpts{Context: ctx}, nil, nil, nil)
+	g.Require.NoError(err, "Failed to filter move events")
+	defer iter.Close()
+	// The root claim is created without a Move event so claim i is created by the i-th event.
+	for i := int64(1); i <= claimIndex; i++ {
+		g.Require.Truef(iter.Next(), "Move event for claim %v not found: %v", claimIndex, iter.Error())
+	}
+	return iter.Event
+}
+
+// AssertMoveEventIndexed checks the Move event emitted when claimIndex was created. The parent index, claim value and
+// claimant must all be present as indexed topics and match the stored claim data.
+func (g *OutputGameHelper) AssertMoveEventIndexed(ctx context.Context, claimIndex int64, expectedClaimant common.Address) {
+	claim := g.getClaim(ctx, claimIndex)
+	event := g.moveEvent(ctx, claimIndex)
+	topics := event.Raw.Topics
+	g.Require.Len(topics, 4, "Move event should have three indexed fields")
+	g.Require.Equal(snapshots.LoadFaultDisputeGameABI().Events["Move"].ID, topics[0], "Incorrect event signature")
+	g.Require.Equal(common.BigToHash(big.NewInt(int64(claim.ParentIndex))), topics[1], "Incorrect indexed parent index")
+	g.Require.Equal(common.Hash(claim.Claim), topics[2], "Incorrect indexed claim")
+	g.Require.Equal(common.BytesToHash(expectedClaimant.Bytes()), topics[3], "Incorrect indexed claimant")
+	g.Require.Equal(expectedClaimant, claim.Claimant, "Incorrect claimant in claim data")
+}
+
+// AssertMoveRejectedAfterClockExpiry advances time until the clock of the claim at parentIndex has expired and then
+// checks that attacking the claim reverts with ClockTimeExceeded().
+func (g *OutputGameHelper) AssertMoveRejectedAfterClockExpiry(ctx context.Context, parentIndex int64) {
+	g.System.AdvanceTime(g.MaxClockDuration(ctx))
+	g.Require.NoError(wait.ForNextBlock(ctx, g.Client))
+
+	parent := g.getClaim(ctx, parentIndex)
+	attackPos := types.NewPositionFromGIndex(parent.Position).Attack()
+	opts := g.makeBondedTransactOpts(ctx, attackPos.ToGIndex(), g.Opts)
+	opts.Context = ctx
+	_, err := g.Game.Attack(opts, big.NewInt(parentIndex), common.Hash{0xee})
+	g.Require.Errorf(err, "Attack on claim %v should be rejected after its clock expired", parentIndex)
+	requireRevertReason(g.Require, err, "ClockTimeExceeded()")
+}
+
+// AssertChallengerNoncesMonotonic checks that the transactions sent by addr and included in the L1 chain since the
+// game was created use strictly sequential nonces. A gap or repeated nonce indicates a bug in transaction management
+// that would stall the challenger.
+func (g *OutputGameHelper) AssertChallengerNoncesMonotonic(ctx context.Context, addr common.Address) {
+	chainID, err := g.Client.ChainID(ctx)
+	g.Require.NoError(err, "Failed to load L1 chain ID")
+	signer := gethtypes.LatestSignerForChainID(chainID)
+	head, err := g.Client.BlockNumber(ctx)
+	g.Require.NoError(err, "Failed to load L1 head")
+
+	var nonces []uint64
+	for num := g.creationBlock(ctx); num <= head; num++ {
+		block, err := g.Client.BlockByNumber(ctx, new(big.Int).SetUint64(num))
+		g.Require.NoErrorf(err, "Failed to load L1 block %v", num)
+		for _, tx := range block.Transactions() {
+			sender, err := gethtypes.Sender(signer, tx)
+			g.Require.NoErrorf(err, "Failed to recover sender of tx %v", tx.Hash())
+			if sender == addr {
+				nonces = append(nonces, tx.Nonce())
+			}
+		}
+	}
+	g.Require.NotEmptyf(nonces, "No transactions found from %v", addr)
+	for i := 1; i < len(nonces); i++ {
+		g.Require.Equalf(nonces[i-1]+1, nonces[i], "Transactions from %v did not use sequential nonces: %v", addr, nonces)
+	}
+}
+
+// successfulCalls counts the successful transactions sent by sender to the game since fromBlock, keyed by the name of
+// the game method called.
+func (g *OutputGameHelper) successfulCalls(ctx context.Context, sender common.Address, fromBlock uint64) map[string]int {
+	gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	g.Require.NoError(err, "Failed to load fault dispute game ABI")
+	chainID, err := g.Client.ChainID(ctx)
+	g.Require.NoError(err, "Failed to load L1 chain ID")
+	signer := gethtypes.LatestSignerForChainID(chainID)
+	head, err := g.Client.BlockNumber(ctx)
+	g.Require.NoError(err, "Failed to load L1 head")
+
+	calls := make(map[string]int)
+	for num := fromBlock; num <= head; num++ {
+		block, err := g.Client.BlockByNumber(ctx, new(big.Int).SetUint64(num))
+		g.Require.NoErrorf(err, "Failed to load L1 block %v", num)
+		for _, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != g.Addr || len(tx.Data()) < 4 {
+				continue
+			}
+			from, err := gethtypes.Sender(signer, tx)
+			g.Require.NoErrorf(err, "Failed to recover sender of tx %v", tx.Hash())
+			if from != sender {
+				continue
+			}
+			method, err := gameAbi.MethodById(tx.Data()[:4])
+			g.Require.NoErrorf(err, "Unknown game method called by tx %v", tx.Hash())
+			receipt, err := g.Client.TransactionReceipt(ctx, tx.Hash())
+			g.Require.NoErrorf(err, "Failed to load receipt for tx %v", tx.Hash())
+			if receipt.Status == gethtypes.ReceiptStatusSuccessful {
+				calls[method.Name]++
+			}
+		}
+	}
+	return calls
+}
+
+// creationBlock returns the number of the L1 block that included the transaction creating the game.
+func (g *OutputGameHelper) creationBlock(ctx context.Context) uint64 {
+	factory, err := bindings.NewDisputeGameFactoryFilterer(g.FactoryAddr, g.Client)
+	g.Require.NoError(err)
+	iter, err := factory.FilterDisputeGameCreated(&bind.FilterOpts{Context: ctx}, []common.Address{g.Addr}, nil, nil)
+	g.Require.NoError(err, "Failed to filter game created events")
+	defer iter.Close()
+	g.Require.Truef(iter.Next(), "No DisputeGameCreated event found for game %v", g.Addr)
+	return iter.Event.Raw.BlockNumber
+}
+
 // Mover is a function that either attacks or defends the claim at parentClaimIdx
 type Mover func(parent *ClaimHelper) *ClaimHelper
 
@@ -544,9 +1024,12 @@ func (g *OutputGameHelper) moveCfg(Opts ...MoveOpt) *moveCfg {
 	return cfg
 }
 
-func (g *OutputGameHelper) Attack(ctx context.Context, claimIdx int64, claim common.Hash, Opts ...MoveOpt) {
+// Attack posts claim as an attack against the claim at claimIdx and returns the gas used by the transaction.
+// Returns 0 if the move was ignored because an identical claim already exists.
+func (g *OutputGameHelper) Attack(ctx context.Context, claimIdx int64, claim common.Hash, Opts ...MoveOpt) uint64 {
 	g.T.Logf("Attacking claim %v with value %v", claimIdx, claim)
 	cfg := g.moveCfg(Opts...)
+	g.requireClaimExists(ctx, claimIdx)
 
 	claimData, err := g.Game.ClaimData(&bind.CallOpts{Context: ctx}, big.NewInt(claimIdx))
 	g.Require.NoError(err, "Failed to get claim data")
@@ -554,20 +1037,24 @@ func (g *OutputGameHelper) Attack(ctx context.Context, claimIdx int64, claim com
 	attackPos := pos.Attack()
 	transactOpts := g.makeBondedTransactOpts(ctx, pos.Attack().ToGIndex(), cfg.Opts)
 
-	err = g.sendMove(ctx, func() (*gethtypes.Transaction, error) {
+	gasUsed, err := g.sendMove(ctx, metrics.MoveTypeAttack, func() (*gethtypes.Transaction, error) {
 		return g.Game.Attack(transactOpts, big.NewInt(claimIdx), claim)
 	})
 	if err != nil {
 		if cfg.ignoreDupes && g.hasClaim(ctx, claimIdx, attackPos, claim) {
-			return
+			return 0
 		}
-		g.Require.NoErrorf(err, "Defend transaction failed. Game state: \n%v", g.GameData(ctx))
+		g.Require.NoErrorf(err, "Attack transaction failed. Game state: \n%v", g.GameData(ctx))
 	}
+	return gasUsed
 }
 
-func (g *OutputGameHelper) Defend(ctx context.Context, claimIdx int64, claim common.Hash, Opts ...MoveOpt) {
+// Defend posts claim as a defense of the claim at claimIdx and returns the gas used by the transaction.
+// Returns 0 if the move was ignored because an identical claim already exists.
+func (g *OutputGameHelper) Defend(ctx context.Context, claimIdx int64, claim common.Hash, Opts ...MoveOpt) uint64 {
 	g.T.Logf("Defending claim %v with value %v", claimIdx, claim)
 	cfg := g.moveCfg(Opts...)
+	g.requireClaimExists(ctx, claimIdx)
 
 	claimData, err := g.Game.ClaimData(&bind.CallOpts{Context: ctx}, big.NewInt(claimIdx))
 	g.Require.NoError(err, "Failed to get claim data")
@@ -575,15 +1062,22 @@ func (g *OutputGameHelper) Defend(ctx context.Context, claimIdx int64, claim com
 	defendPos := pos.Defend()
 	transactOpts := g.makeBondedTransactOpts(ctx, defendPos.ToGIndex(), cfg.Opts)
 
-	err = g.sendMove(ctx, func() (*gethtypes.Transaction, error) {
+	gasUsed, err := g.sendMove(ctx, metrics.MoveTypeDefend, func() (*gethtypes.Transaction, error) {
 		return g.Game.Defend(transactOpts, big.NewInt(claimIdx), claim)
 	})
 	if err != nil {
 		if cfg.ignoreDupes && g.hasClaim(ctx, claimIdx, defendPos, claim) {
-			return
+			return 0
 		}
 		g.Require.NoErrorf(err, "Defend transaction failed. Game state: \n%v", g.GameData(ctx))
 	}
+	return gasUsed
+}
+
+// requireClaimExists fails the test if there is no claim at claimIdx in the game.
+func (g *OutputGameHelper) requireClaimExists(ctx context.Context, claimIdx int64) {
+	count := g.getClaimCount(ctx)
+	g.Require.Truef(claimIdx >= 0 && claimIdx < count, "Claim index %v out of range, game %v has %v claims", claimIdx, g.Addr, count)
 }
 
 func (g *OutputGameHelper) hasClaim(ctx context.Context, parentIdx int64, pos types.Position, value common.Hash) bool {
@@ -596,16 +1090,19 @@ func (g *OutputGameHelper) hasClaim(ctx context.Context, parentIdx int64, pos ty
 	return false
 }
 
-func (g *OutputGameHelper) sendMove(ctx context.Context, send func() (*gethtypes.Transaction, error)) error {
+// sendMove sends the move transaction and waits for it to be included, returning the gas used by the transaction.
+// The gas used is also recorded against moveType in the helper's Metrics.
+func (g *OutputGameHelper) sendMove(ctx context.Context, moveType string, send func() (*gethtypes.Transaction, error)) (uint64, error) {
 	tx, err := send()
 	if err != nil {
-		return fmt.Errorf("transaction did not send: %w", err)
+		return 0, fmt.Errorf("transaction did not send: %w", err)
 	}
-	_, err = wait.ForReceiptOK(ctx, g.Client, tx.Hash())
+	rcpt, err := wait.ForReceiptOK(ctx, g.Client, tx.Hash())
 	if err != nil {
-		return fmt.Errorf("transaction was not ok: %w", err)
+		return 0, fmt.Errorf("transaction was not ok: %w", err)
 	}
-	return nil
+	g.Metrics.RecordChallengerGasUsed(moveType, rcpt.GasUsed)
+	return rcpt.GasUsed, nil
 }
 
 func (g *OutputGameHelper) makeBondedTransactOpts(ctx context.Context, pos *big.Int, Opts *bind.TransactOpts) *bind.TransactOpts {
@@ -616,10 +1113,54 @@ func (g *OutputGameHelper) makeBondedTransactOpts(ctx context.Context, pos *big.
 	return &bOpts
 }
 
+// AssertBondSchedule checks that the bond required for a claim at each depth exactly matches the expected schedule.
+// expected[i] is the required bond at depth i. Only the depths included in expected are checked.
+func (g *OutputGameHelper) AssertBondSchedule(ctx context.Context, expected []*big.Int) {
+	g.Require.LessOrEqualf(len(expected)-1, int(g.MaxDepth(ctx)), "Expected bond schedule exceeds max game depth")
+	for depth, expectedBond := range expected {
+		pos := types.NewPosition(types.Depth(depth), big.NewInt(0))
+		bond, err := g.Game.GetRequiredBond(&bind.CallOpts{Context: ctx}, pos.ToGIndex())
+		g.Require.NoErrorf(err, "Failed to get required bond at depth %v", depth)
+		g.Require.Truef(expectedBond.Cmp(bond) == 0, "Incorrect bond at depth %v, expected %v but was %v", depth, expectedBond, bond)
+	}
+}
+
 type ErrWithData interface {
 	ErrorData() interface{}
 }
 
+// requireRevertReason checks that err is a revert with the ABI encoded custom error matching errSignature,
+// e.g. "ValidStep()".
+func requireRevertReason(require *require.Assertions, err error, errSignature string) {
+	errData, ok := err.(ErrWithData)
+	require.Truef(ok, "Error should provide ErrorData method: %v", err)
+	expected := hexutil.Encode(crypto.Keccak256([]byte(errSignature))[:4])
+	require.Equalf(expected, errData.ErrorData(), "Revert reason should be abi encoded %v", errSignature)
+}
+
+// Step calls step against the claim at claimIdx using the supplied pre-state and proof data, and waits for the
+// transaction to be included. If the step reverts, the test fails with the revert data so invalid steps are easy to
+// diagnose. Returns the gas used by the step transaction.
+func (g *OutputGameHelper) Step(ctx context.Context, claimIdx int64, isAttack bool, stateData []byte, proof []byte) uint64 {
+	gasUsed, err := g.TryStep(ctx, claimIdx, isAttack, stateData, proof)
+	g.Require.NoErrorf(err, "Step transaction failed. Game state: \n%v", g.GameData(ctx))
+	return gasUsed
+}
+
+// TryStep calls step in the same way as Step but returns any error instead of failing the test. If the step reverts
+// the error message includes the revert data and the error still provides it via ErrWithData.
+func (g *OutputGameHelper) TryStep(ctx context.Context, claimIdx int64, isAttack bool, stateData []byte, proof []byte) (uint64, error) {
+	g.T.Logf("Stepping against claim %v isAttack: %v", claimIdx, isAttack)
+	gasUsed, err := g.sendMove(ctx, metrics.MoveTypeStep, func() (*gethtypes.Transaction, error) {
+		return g.Game.Step(g.Opts, big.NewInt(claimIdx), isAttack, stateData, proof)
+	})
+	var errData ErrWithData
+	if errors.As(err, &errData) {
+		return 0, fmt.Errorf("step reverted with %v: %w", errData.ErrorData(), err)
+	}
+	return gasUsed, err
+}
+
 // StepFails attempts to call step and verifies that it fails with ValidStep()
 func (g *OutputGameHelper) StepFails(claimIdx int64, isAttack bool, stateData []byte, proof []byte) {
 	g.T.Logf("Attempting step against claim %v isAttack: %v", claimIdx, isAttack)
@@ -629,7 +1170,8 @@ func (g *OutputGameHelper) StepFails(claimIdx int64, isAttack bool, stateData []
 	g.Require.Equal("0xfb4e40dd", errData.ErrorData(), "Revert reason should be abi encoded ValidStep()")
 }
 
-// ResolveClaim resolves a single subgame
+// ResolveClaim resolves a single subgame and waits for the transaction to be included.
+// All claims that counter it must have been resolved first.
 func (g *OutputGameHelper) ResolveClaim(ctx context.Context, claimIdx int64) {
 	tx, err := g.Game.ResolveClaim(g.Opts, big.NewInt(claimIdx), common.Big0)
 	g.Require.NoError(err, "ResolveClaim transaction did not send")
@@ -637,6 +1179,52 @@ func (g *OutputGameHelper) ResolveClaim(ctx context.Context, claimIdx int64) {
 	g.Require.NoError(err, "ResolveClaim transaction was not OK")
 }
 
+// resolveAndMeasureGas resolves every subgame, from the deepest claims up to the root claim, and then the game itself.
+// Returns the gas used by the resolveClaim call for each claim, indexed by claim index.
+func (g *OutputGameHelper) resolveAndMeasureGas(ctx context.Context) []uint64 {
+	count := g.getClaimCount(ctx)
+	gas := make([]uint64, count)
+	for i := count - 1; i >= 0; i-- {
+		tx, err := g.Game.ResolveClaim(g.Opts, big.NewInt(i), common.Big0)
+		g.Require.NoError(err, "ResolveClaim transaction did not send")
+		rcpt, err := wait.ForReceiptOK(ctx, g.Client, tx.Hash())
+		g.Require.NoError(err, "ResolveClaim transaction was not OK")
+		gas[i] = rcpt.GasUsed
+	}
+	g.Resolve(ctx)
+	return gas
+}
+
+// ResolveExternally resolves every subgame, from the deepest claims up to the root claim, and then the game itself
+// using the helper's own transact opts. This simulates a third party resolving the game rather than the challenger.
+// The clocks of all claims must have already expired.
+func (g *OutputGameHelper) ResolveExternally(ctx context.Context) {
+	for i := g.getClaimCount(ctx) - 1; i >= 0; i-- {
+		g.ResolveClaim(ctx, i)
+	}
+	g.Resolve(ctx)
+	g.T.Logf("Game %v resolved externally with status %v", g.Addr, g.Status(ctx))
+}
+
+// AccountNonce returns the nonce of the account as of the latest L1 block.
+func (g *OutputGameHelper) AccountNonce(ctx context.Context, account common.Address) uint64 {
+	nonce, err := g.Client.NonceAt(ctx, account, nil)
+	g.Require.NoErrorf(err, "Failed to get nonce of %v", account)
+	return nonce
+}
+
+// AssertChallengerHandlesExternalResolution checks that once the game has been resolved by someone else, the
+// challenger stops interacting with it rather than continuing to send transactions. The challenger's nonce must be
+// captured with AccountNonce before the challenger is started and must still be unchanged after several more blocks.
+func (g *OutputGameHelper) AssertChallengerHandlesExternalResolution(ctx context.Context, challengerAddr common.Address, nonce uint64) {
+	g.Require.NotEqual(StatusInProgress, g.Status(ctx), "Game %v should have been resolved", g.Addr)
+	g.WaitForInactivity(ctx, 5, false)
+	g.Require.Equalf(nonce, g.AccountNonce(ctx, challengerAddr), "Challenger %v sent transactions after game %v was resolved", challengerAddr, g.Addr)
+	pending, err := g.Client.PendingNonceAt(ctx, challengerAddr)
+	g.Require.NoError(err, "Failed to get pending nonce")
+	g.Require.Equalf(nonce, pending, "Challenger %v has pending transactions after game %v was resolved", challengerAddr, g.Addr)
+}
+
 // ChallengePeriod returns the challenge period fetched from the PreimageOracle contract.
 // The returned uint64 value is the number of seconds for the challenge period.
 func (g *OutputGameHelper) ChallengePeriod(ctx context.Context) uint64 {
@@ -720,16 +1308,56 @@ func (g *OutputGameHelper) oracle(ctx context.Context) *contracts.PreimageOracle
 	return oracle
 }
 
+// AssertAnchorUpdatedAfterResolution checks that the anchor state registry used by the game has been updated to
+// expectedRoot at the game's L2 block number. Skips the test if the game has no anchor state registry.
+func (g *OutputGameHelper) AssertAnchorUpdatedAfterResolution(ctx context.Context, expectedRoot common.Hash) {
+	registryAddr, err := g.Game.AnchorStateRegistry(&bind.CallOpts{Context: ctx})
+	g.Require.NoError(err, "Failed to load anchor state registry address")
+	if registryAddr == (common.Address{}) {
+		g.T.Skip("Game has no anchor state registry")
+	}
+	code, err := g.Client.CodeAt(ctx, registryAddr, nil)
+	g.Require.NoError(err, "Failed to load anchor state registry code")
+	if len(code) == 0 {
+		g.T.Skipf("No anchor state registry deployed at %v", registryAddr)
+	}
+	gameType, err := g.Game.GameType(&bind.CallOpts{Context: ctx})
+	g.Require.NoError(err, "Failed to load game type")
+
+	caller := batching.NewMultiCaller(g.System.NodeClient("l1").Client(), batching.DefaultBatchSize)
+	registry := batching.NewBoundContract(snapshots.LoadAnchorStateRegistryABI(), registryAddr)
+	result, err := caller.SingleCall(ctx, rpcblock.Latest, registry.Call("anchors", gameType))
+	g.Require.NoError(err, "Failed to load anchor state")
+	g.Require.Equal(expectedRoot, result.GetHash(0), "Anchor root was not updated")
+	g.Require.Equal(g.L2BlockNum(ctx), result.GetBigInt(1).Uint64(), "Anchor L2 block number was not updated")
+}
+
+// GameData returns a description of the game and each of its claims for debugging.
+// Errors loading data are included in the description instead of failing the test, so as much of the game as
+// possible is reported and it is safe to use from t.Cleanup.
 func (g *OutputGameHelper) GameData(ctx context.Context) string {
-	Opts := &bind.CallOpts{Context: ctx}
-	maxDepth := g.MaxDepth(ctx)
-	splitDepth := g.SplitDepth(ctx)
-	claimCount, err := g.Game.ClaimDataLen(Opts)
+	opts := &bind.CallOpts{Context: ctx}
+	maxDepthBig, err := g.Game.MaxGameDepth(opts)
+	if err != nil {
+		return fmt.Sprintf("Game %v - failed to load max depth: %v\n", g.Addr, err)
+	}
+	maxDepth := types.Depth(maxDepthBig.Uint64())
+	splitDepthBig, err := g.Game.SplitDepth(opts)
+	if err != nil {
+		return fmt.Sprintf("Game %v - failed to load split depth: %v\n", g.Addr, err)
+	}
+	splitDepth := types.Depth(splitDepthBig.Uint64())
+	claimCount, err := g.Game.ClaimDataLen(opts)
+	if err != nil {
+		return fmt.Sprintf("Game %v - failed to load claim count: %v\n", g.Addr, err)
+	}
 	info := fmt.Sprintf("Claim count: %v\n", claimCount)
-	g.Require.NoError(err, "Fetching claim count")
 	for i := int64(0); i < claimCount.Int64(); i++ {
-		claim, err := g.Game.ClaimData(Opts, big.NewInt(i))
-		g.Require.NoErrorf(err, "Fetch claim %v", i)
+		claim, err := g.Game.ClaimData(opts, big.NewInt(i))
+		if err != nil {
+			info = info + fmt.Sprintf("%v - Failed to load claim: %v\n", i, err)
+			continue
+		}
 
 		pos := types.NewPositionFromGIndex(claim.Position)
 		extra := ""
@@ -740,16 +1368,23 @@ func (g *OutputGameHelper) GameData(ctx context.Context) string {
 				extra = fmt.Sprintf("Block num: %v", blockNum)
 			}
 		}
-		info = info + fmt.Sprintf("%v - Position: %v, Depth: %v, IndexAtDepth: %v Trace Index: %v, ClaimHash: %v, Countered By: %v, ParentIndex: %v Claimant: %v Bond: %v %v\n",
-			i, claim.Position.Int64(), pos.Depth(), pos.IndexAtDepth(), pos.TraceIndex(maxDepth), common.Hash(claim.Claim).Hex(), claim.CounteredBy, claim.ParentIndex, claim.Claimant, claim.Bond, extra)
+		info = info + fmt.Sprintf("%v - Position: %v, Depth: %v, IndexAtDepth: %v Trace Index: %v, ClaimHash: %v, Countered By: %v, ParentIndex: %v Claimant: %v Bond: %v Clock: %v %v\n",
+			i, claim.Position.Int64(), pos.Depth(), pos.IndexAtDepth(), pos.TraceIndex(maxDepth), common.Hash(claim.Claim).Hex(), claim.CounteredBy, claim.ParentIndex, claim.Claimant, claim.Bond, claim.Clock, extra)
+	}
+	l2BlockNum := "<unknown>"
+	if blockNum, err := g.Game.L2BlockNumber(opts); err == nil {
+		l2BlockNum = blockNum.String()
+	}
+	status := "<unknown>"
+	if s, err := g.Game.Status(opts); err == nil {
+		status = Status(s).String()
 	}
-	l2BlockNum := g.L2BlockNum(ctx)
-	status, err := g.Game.Status(Opts)
-	g.Require.NoError(err, "Load game status")
 	return fmt.Sprintf("Game %v - %v - L2 Block: %v - Split Depth: %v - Max Depth: %v:\n%v\n",
-		g.Addr, Status(status), l2BlockNum, splitDepth, maxDepth, info)
+		g.Addr, status, l2BlockNum, splitDepth, maxDepth, info)
 }
 
+// LogGameData logs every claim in the game. It does not fail the test if game data can't be loaded so it can be
+// registered with t.Cleanup to dump the game state when a test fails.
 func (g *OutputGameHelper) LogGameData(ctx context.Context) {
 	g.T.Log(g.GameData(ctx))
 }
@@ -761,6 +1396,45 @@ func (g *OutputGameHelper) Credit(ctx context.Context, addr common.Address) *big
 	return amt
 }
 
+// ClaimCredit claims the credit owed to recipient and waits for the transaction to be included.
+// The game must already be resolved and the DelayedWETH unlock delay must have passed.
+func (g *OutputGameHelper) ClaimCredit(ctx context.Context, recipient common.Address) {
+	g.Require.NotEqualf(StatusInProgress, g.Status(ctx), "Game %v must be resolved before claiming credit", g.Addr)
+	tx, err := g.Game.ClaimCredit(g.Opts, recipient)
+	g.Require.NoErrorf(err, "ClaimCredit transaction did not send for %v", recipient)
+	_, err = wait.ForReceiptOK(ctx, g.Client, tx.Hash())
+	g.Require.NoErrorf(err, "ClaimCredit transaction was not OK for %v", recipient)
+}
+
+// ClaimCreditFails attempts to claim the credit owed to recipient and verifies that it fails with NoCreditToClaim()
+func (g *OutputGameHelper) ClaimCreditFails(recipient common.Address) {
+	g.T.Logf("Attempting to claim credit for %v", recipient)
+	_, err := g.Game.ClaimCredit(g.Opts, recipient)
+	requireRevertReason(g.Require, err, "NoCreditToClaim()")
+}
+
+// AssertResolverCompensated advances time until all clocks have expired, waits for the game to be resolved and
+// checks that resolver sent the successful resolveClaim and resolve transactions and that its credit increased as a
+// result. FaultDisputeGame only compensates resolvers through the bonds of the claims they countered, so the check is
+// skipped if resolver has not posted any claims in the game.
+func (g *OutputGameHelper) AssertResolverCompensated(ctx context.Context, resolver common.Address) {
+	g.Require.Equalf(StatusInProgress, g.Status(ctx), "Game %v must not be resolved before checking resolver compensation", g.Addr)
+	if !slices.ContainsFunc(g.getAllClaims(ctx), func(claim ContractClaim) bool { return claim.Claimant == resolver }) {
+		g.T.Skipf("Game %v does not compensate resolver %v as it posted no claims", g.Addr, resolver)
+	}
+	before := g.Credit(ctx, resolver)
+	start, err := g.Client.BlockNumber(ctx)
+	g.Require.NoError(err, "Failed to load L1 head")
+	g.System.AdvanceTime(g.MaxClockDuration(ctx))
+	g.Require.NoError(wait.ForNextBlock(ctx, g.Client))
+	g.WaitForResolved(ctx)
+	calls := g.successfulCalls(ctx, resolver, start)
+	g.Require.Positivef(calls["resolveClaim"], "Expected resolver %v to resolve claims in game %v", resolver, g.Addr)
+	g.Require.Equalf(1, calls["resolve"], "Expected resolver %v to resolve game %v", resolver, g.Addr)
+	after := g.Credit(ctx, resolver)
+	g.Require.Truef(after.Cmp(before) > 0, "Expected resolver %v credit to increase from %v but was %v\n%v", resolver, before, after, g.GameData(ctx))
+}
+
 func (g *OutputGameHelper) GetL1Head(ctx context.Context) eth.BlockID {
 	l1HeadHash, err := g.Game.L1Head(&bind.CallOpts{Context: ctx})
 	g.Require.NoError(err, "Failed to load L1 head")
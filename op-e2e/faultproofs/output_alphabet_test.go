@@ -6,12 +6,21 @@ import (
 	"testing"
 	"time"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/challenger"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/disputegame"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -72,6 +81,32 @@ func TestOutputAlphabetGame_ChallengerWins(t *testing.T) {
 	game.LogGameData(ctx)
 }
 
+func TestOutputAlphabetGame_ClaimAccessors(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	require.Equal(t, common.Hash{0xff}, game.GetClaimValue(ctx, 0))
+	attackPos := faultTypes.NewPositionFromGIndex(big.NewInt(1)).Attack()
+	_, _, ok := game.GetClaimByPosition(ctx, attackPos.ToGIndex())
+	require.False(t, ok, "Should not find a claim before the root is countered")
+
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// The challenger disagrees with the root claim so counters it with a different value
+	counterIdx := game.WaitForClaim(ctx, func(index int64, claim common.Hash) bool {
+		return index > 0 && claim != common.Hash{0xff}
+	})
+	require.EqualValues(t, 1, counterIdx)
+	idx, value, ok := game.GetClaimByPosition(ctx, attackPos.ToGIndex())
+	require.True(t, ok, "Should find the counter claim at the attack position")
+	require.Equal(t, counterIdx, idx)
+	require.Equal(t, game.GetClaimValue(ctx, counterIdx), value)
+}
+
 func TestOutputAlphabetGame_ReclaimBond(t *testing.T) {
 	op_e2e.InitParallel(t)
 	ctx := context.Background()
@@ -133,6 +168,125 @@ func TestOutputAlphabetGame_ReclaimBond(t *testing.T) {
 	require.True(t, game.WethBalance(ctx, game.Addr).Cmp(big.NewInt(0)) == 0)
 }
 
+func TestOutputAlphabetGame_ClaimCredit(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.LogGameData(ctx)
+
+	alice := sys.Cfg.Secrets.Addresses().Alice
+	c := game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Post a bonded attack for the challenger to counter
+	claim := game.RootClaim(ctx).WaitForCounterClaim(ctx)
+	claim = claim.Attack(ctx, common.Hash{0xaa})
+	claim.WaitForCounterClaim(ctx)
+	game.LogGameData(ctx)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+	game.LogGameData(ctx)
+
+	// Stop the challenger so it doesn't claim its own credit once the unlock delay passes
+	require.NoError(t, c.Close())
+
+	// The challenger is paid the bond of the attack it countered, while the root claim required no bond
+	require.Truef(t, game.Credit(ctx, alice).Cmp(big.NewInt(0)) > 0, "Expected challenger credit to be above zero")
+	require.Zero(t, game.Credit(ctx, disputegame.TestAddress).Sign(), "Expected proposer to have no credit")
+	game.ClaimCreditFails(disputegame.TestAddress)
+
+	sys.TimeTravelClock.AdvanceTime(game.CreditUnlockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.ClaimCredit(ctx, alice)
+	require.Zero(t, game.Credit(ctx, alice).Sign(), "Expected no credit remaining after claiming")
+	require.Zero(t, game.WethBalance(ctx, game.Addr).Sign(), "Expected all bonds to be withdrawn from DelayedWETH")
+}
+
+func TestOutputAlphabetGame_ResolveClaimsInOrder(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.Attack(ctx, 0, common.Hash{0x01})
+	game.WaitForClaimCount(ctx, 2)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+
+	// Claims must be resolved bottom-up before the game itself
+	game.ResolveClaim(ctx, 1)
+	game.ResolveClaim(ctx, 0)
+	require.Equal(t, disputegame.StatusInProgress, game.Status(ctx))
+	game.Resolve(ctx)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+}
+
+func TestOutputAlphabetGame_WaitForResolved(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", 1)
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	require.Equal(t, disputegame.StatusDefenderWins, game.WaitForResolved(ctx))
+}
+
+func TestOutputAlphabetGame_ChallengerQuiescesBeforeResolution(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Dishonest actor makes a single attack which the challenger counters, after which there is nothing left to do
+	claim := game.RootClaim(ctx).WaitForCounterClaim(ctx)
+	claim.Attack(ctx, common.Hash{0x01}).WaitForCounterClaim(ctx)
+
+	stableCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	game.WaitForClaimCountStable(stableCtx, time.Second, 10*time.Second)
+	game.WaitForClaimCountStaysAt(ctx, 4, time.Second)
+	game.LogGameData(ctx)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	require.Equal(t, disputegame.StatusChallengerWins, game.WaitForResolved(ctx))
+}
+
+func TestOutputAlphabetGame_StepRevertReported(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+
+	// Stepping against the root claim is invalid as it is not at the max game depth
+	_, err := game.TryStep(ctx, 0, true, []byte{0x01}, nil)
+	var errData disputegame.ErrWithData
+	require.ErrorAs(t, err, &errData)
+	invalidParent := hexutil.Encode(crypto.Keccak256([]byte("InvalidParent()"))[:4])
+	require.Equal(t, invalidParent, errData.ErrorData())
+	require.ErrorContains(t, err, invalidParent, "Revert data should be included in the error message")
+}
+
 func TestOutputAlphabetGame_ValidOutputRoot(t *testing.T) {
 	op_e2e.InitParallel(t)
 	ctx := context.Background()
@@ -164,6 +318,37 @@ func TestOutputAlphabetGame_ValidOutputRoot(t *testing.T) {
 	game.WaitForGameStatus(ctx, disputegame.StatusDefenderWins)
 }
 
+func TestOutputAlphabetGame_DefenderWinsAgainstDishonestAlphabet(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", 2)
+	game.LogGameData(ctx)
+	claim := game.DisputeLastBlock(ctx)
+	// Invalid root claim of the alphabet game
+	alphabetRoot := claim.Attack(ctx, common.Hash{0x01})
+
+	game.StartChallenger(ctx, "sequencer", "Honest", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	counter := alphabetRoot.WaitForCounterClaim(ctx)
+
+	// Corrupt the first trace index bisected to so the dishonest actor disagrees with the honest challenger's first
+	// bottom game move and counters it with an incorrect claim.
+	execDepth := uint64(game.MaxDepth(ctx) - game.SplitDepth(ctx) - 1)
+	corruptIndex := uint64(1)<<(execDepth-1) - 1
+	dishonest := game.CreateDishonestAlphabetActor(ctx, corruptIndex)
+	dishonest.CounterClaim(ctx, counter)
+
+	game.WaitForInactivity(ctx, 10, true)
+	game.LogGameData(ctx)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusDefenderWins)
+}
+
 func TestChallengerCompleteExhaustiveDisputeGame(t *testing.T) {
 	op_e2e.InitParallel(t)
 
@@ -293,3 +478,625 @@ func TestOutputAlphabetGame_FreeloaderEarnsNothing(t *testing.T) {
 	amt := game.Credit(ctx, freeloaderOpts.From)
 	require.True(t, amt.BitLen() == 0, "freeloaders should not be rewarded")
 }
+
+func TestOutputAlphabetGame_ChallengerNoncesMonotonic(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.LogGameData(ctx)
+
+	claim := game.RootClaim(ctx)
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Perform a few moves so the challenger has to send multiple transactions
+	claim = claim.WaitForCounterClaim(ctx)
+	claim = claim.Attack(ctx, common.Hash{0x01})
+	_ = claim.Attack(ctx, common.Hash{0x02})
+	game.LogGameData(ctx)
+
+	game.AssertChallengerNoncesMonotonic(ctx, sys.Cfg.Secrets.Addresses().Alice)
+}
+
+func TestOutputAlphabetGame_ResolverCompensated(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	game.RootClaim(ctx).WaitForCounterClaim(ctx)
+	game.LogGameData(ctx)
+
+	// The challenger resolves the claims and the game, and is credited the bond of the root claim it countered
+	game.AssertResolverCompensated(ctx, sys.Cfg.Secrets.Addresses().Alice)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+	game.LogGameData(ctx)
+}
+
+func TestOutputAlphabetGame_ChallengerHandlesExternalResolution(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	claim := game.RootClaim(ctx)
+
+	c := game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	claim.WaitForCounterClaim(ctx)
+	game.LogGameData(ctx)
+
+	// Stop the challenger so it can't resolve the game itself
+	require.NoError(t, c.Close())
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.ResolveExternally(ctx)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+
+	// Restart the challenger and check it leaves the resolved game alone and shuts down cleanly
+	alice := sys.Cfg.Secrets.Addresses().Alice
+	nonce := game.AccountNonce(ctx, alice)
+	c = game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	game.AssertChallengerHandlesExternalResolution(ctx, alice, nonce)
+	require.NoError(t, c.Close())
+}
+
+// startReconfiguredAlphabetGame starts a fault dispute system, replaces its alphabet game implementation as set by opts
+// and creates an alphabet game with an invalid root claim using the new implementation.
+func startReconfiguredAlphabetGame(t *testing.T, ctx context.Context, opts ...disputegame.FactoryOpt) (*op_e2e.System, *ethclient.Client, *disputegame.OutputAlphabetGameHelper) {
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	opts = append([]disputegame.FactoryOpt{disputegame.WithFactoryOwner(sys.Cfg.Secrets.CliqueSigner)}, opts...)
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys, opts...)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	return sys, l1Client, game
+}
+
+func TestOutputAlphabetGame_ChallengerRefusesPrestateMismatch(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _, game := startReconfiguredAlphabetGame(t, ctx, disputegame.WithAlphabetAbsolutePrestate(common.Hash{0x03, 0xaa}))
+	game.LogGameData(ctx)
+
+	c := game.StartChallengerWithWrongPrestate(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	game.AssertChallengerErrorsOnPrestateMismatch(ctx, c)
+}
+
+func TestOutputAlphabetGame_ShortPollInterval(t *testing.T) {
+	op_e2e.InitParallel(t)
+	if !op_e2e.UseHTTP() {
+		t.Skip("Poll interval only applies when connected to L1 over HTTP")
+	}
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	timeToCounter := func(game *disputegame.OutputAlphabetGameHelper, options ...challenger.Option) time.Duration {
+		start := time.Now()
+		game.StartChallenger(ctx, "sequencer", "Challenger", options...)
+		game.RootClaim(ctx).WaitForCounterClaim(ctx)
+		return time.Since(start)
+	}
+
+	shortGame := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff, 0x01})
+	short := timeToCounter(shortGame,
+		challenger.WithPrivKey(sys.Cfg.Secrets.Alice),
+		challenger.WithPollInterval(100*time.Millisecond))
+	defaultGame := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff, 0x02})
+	defaultTime := timeToCounter(defaultGame, challenger.WithPrivKey(sys.Cfg.Secrets.Bob))
+	t.Logf("Challenger countered root claim after %v with a short poll interval and %v with the default", short, defaultTime)
+	require.Less(t, short, defaultTime, "Short poll interval should counter the root claim sooner")
+
+	sys.TimeTravelClock.AdvanceTime(shortGame.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	shortGame.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+}
+
+func TestOutputAlphabetGame_GameImplConfig(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	deployed := disputegame.NewFactoryHelper(t, ctx, sys)
+	alphabetVM, alphabetPrestate := deployed.GameImplConfig(ctx, faultTypes.AlphabetGameType)
+	cannonVM, _ := deployed.GameImplConfig(ctx, faultTypes.CannonGameType)
+	require.NotEqual(t, alphabetVM, cannonVM, "Alphabet and cannon games should use different VMs")
+
+	// Misconfigure the alphabet game to use the cannon VM and a prestate the alphabet trace can't produce
+	prestate := common.Hash{0x03, 0xaa}
+	require.NotEqual(t, alphabetPrestate, prestate)
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys,
+		disputegame.WithFactoryOwner(sys.Cfg.Secrets.CliqueSigner),
+		disputegame.WithAlphabetVM(cannonVM),
+		disputegame.WithAlphabetAbsolutePrestate(prestate))
+	vm, actualPrestate := disputeGameFactory.GameImplConfig(ctx, faultTypes.AlphabetGameType)
+	require.Equal(t, cannonVM, vm)
+	require.Equal(t, prestate, actualPrestate)
+
+	// A challenger requiring a valid prestate rejects games created by the misconfigured factory for their alphabet prestate
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xff})
+	c := game.StartChallengerWithWrongPrestate(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	game.AssertChallengerErrorsOnPrestateMismatch(ctx, c)
+}
+
+func TestOutputAlphabetGame_AttachFactoryHelper(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xaa})
+
+	attached := disputegame.AttachFactoryHelper(t, ctx, sys, disputeGameFactory.FactoryAddr)
+	require.Equal(t, disputeGameFactory.FactoryAddr, attached.FactoryAddr)
+	count := attached.GameCount(ctx)
+	require.Equal(t, disputeGameFactory.GameCount(ctx), count)
+	addr, _ := attached.GetGame(ctx, count-1)
+	require.Equal(t, game.Addr, addr)
+}
+
+func TestOutputAlphabetGame_FactoryRejectsShallowGame(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xff})
+	disputeGameFactory.RequireRejectsShallowDepth(ctx, faultTypes.AlphabetGameType, uint64(game.SplitDepth(ctx)))
+}
+
+func TestOutputAlphabetGame_ChallengerWinsAtDepthSix(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client, game := startReconfiguredAlphabetGame(t, ctx, disputegame.WithAlphabetGameDepth(6, 3))
+	require.EqualValues(t, 6, game.MaxDepth(ctx))
+	require.EqualValues(t, 3, game.SplitDepth(ctx))
+	correctTrace := game.CreateHonestActor(ctx, "sequencer")
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Counter every honest output root down to the leaf level of the top game
+	claim := game.RootClaim(ctx)
+	for claim.IsOutputRoot(ctx) && !claim.IsOutputRootLeaf(ctx) {
+		if claim.AgreesWithOutputRoot() {
+			claim = claim.WaitForCounterClaim(ctx)
+		} else {
+			claim = claim.Attack(ctx, common.Hash{0xaa})
+		}
+	}
+
+	// Then counter every honest claim in the alphabet trace down to max depth
+	claim = claim.WaitForCounterClaim(ctx)
+	claim = correctTrace.AttackClaim(ctx, claim)
+	for !claim.IsMaxDepth(ctx) {
+		if claim.AgreesWithOutputRoot() {
+			claim = claim.WaitForCounterClaim(ctx)
+		} else {
+			claim = correctTrace.AttackClaim(ctx, claim)
+		}
+	}
+	require.EqualValues(t, 6, claim.Depth())
+	claim.WaitForCountered(ctx)
+	game.LogGameData(ctx)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+}
+
+func TestOutputAlphabetGame_ChallengerProcessesManyGames(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	initialCount := disputeGameFactory.GameCount(ctx)
+	games := disputeGameFactory.CreateManyGames(ctx, "sequencer", 1, 10)
+	require.Equal(t, initialCount+int64(len(games)), disputeGameFactory.GameCount(ctx))
+	for i, game := range games {
+		addr, gameType := disputeGameFactory.GetGame(ctx, initialCount+int64(i))
+		require.Equal(t, game.Addr, addr)
+		require.Equal(t, faultTypes.AlphabetGameType, gameType)
+	}
+
+	disputeGameFactory.StartChallenger(ctx, "Challenger",
+		challenger.WithAlphabet(sys.RollupEndpoint("sequencer")),
+		challenger.WithPrivKey(sys.Cfg.Secrets.Alice),
+	)
+	disputeGameFactory.AssertChallengerProcessesAllWithin(ctx, 2*time.Minute, games...)
+}
+
+func TestOutputAlphabetGame_ResolutionMatchesTrace(t *testing.T) {
+	op_e2e.InitParallel(t)
+
+	tests := []struct {
+		name            string
+		claimedAlphabet string
+		honest          bool
+	}{
+		{name: "HonestIncorrectTrace", claimedAlphabet: "abcdefgx", honest: true},
+		{name: "DishonestIncorrectTrace", claimedAlphabet: "abcdefgx", honest: false},
+		{name: "DishonestCorrectTrace", claimedAlphabet: "abcdefgh", honest: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			op_e2e.InitParallel(t)
+			ctx := context.Background()
+			sys, l1Client := StartFaultDisputeSystem(t)
+			t.Cleanup(sys.Close)
+
+			disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+			game := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", 2)
+			bottomRoot := game.AttackWithAlphabet(ctx, game.DisputeLastBlock(ctx), test.claimedAlphabet)
+			game.LogGameData(ctx)
+
+			if test.honest {
+				game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+				bottomRoot.WaitForCounterClaim(ctx)
+				game.WaitForInactivity(ctx, 10, true)
+			}
+
+			sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+			require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+			if !test.honest {
+				game.ResolveExternally(ctx)
+			}
+			game.AssertResolutionMatchesTrace(ctx, test.claimedAlphabet, test.honest)
+		})
+	}
+}
+
+func TestOutputAlphabetGame_TwoGamesSameOutput(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game1, game2 := disputeGameFactory.StartTwoGamesSameOutput(ctx, "sequencer", 2)
+
+	disputeGameFactory.StartChallenger(ctx, "Challenger",
+		challenger.WithAlphabet(sys.RollupEndpoint("sequencer")),
+		challenger.WithPrivKey(sys.Cfg.Secrets.Alice),
+	)
+	disputeGameFactory.AssertChallengerActsOnBoth(ctx, game1, game2)
+
+	sys.TimeTravelClock.AdvanceTime(game1.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game1.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+	game2.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+}
+
+func TestOutputAlphabetGame_RejectsBadExtraData(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	// Extra data should be a 32 byte L2 block number, so truncate it
+	disputeGameFactory.StartGameWithBadExtraData(ctx, common.Hash{0xff}, make([]byte, 16))
+}
+
+func TestOutputAlphabetGame_ChallengerRestartedRepeatedly(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	claim := game.RootClaim(ctx)
+
+	c := game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	claim.WaitForCounterClaim(ctx)
+
+	// Restart several times while the challenger is still responding to claims
+	c.RestartManyTimes(ctx, 3)
+	game.WaitForInactivity(ctx, 10, true)
+	game.LogGameData(ctx)
+	game.AssertNoDuplicateMovesAfterRestarts(ctx)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+	game.AssertNoDuplicateMovesAfterRestarts(ctx)
+}
+
+// mixedAgreement lists, for each depth, whether PlayMixedHonest posts the correct claim.
+var mixedAgreement = []bool{true, true, false, true, false, true, true, false, true, true}
+
+func TestOutputAlphabetGame_MixedAgreement(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	// Agree with the challenger at some depths and dispute it at others
+	game.PlayMixedHonest(ctx, "sequencer", mixedAgreement)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.AssertHonestWins(ctx)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+}
+
+func TestOutputAlphabetGame_AnchorUpdatedAfterDefenderWins(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", 2)
+	rootClaim := game.GetClaimValue(ctx, 0)
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusDefenderWins)
+	game.AssertAnchorUpdatedAfterResolution(ctx, rootClaim)
+}
+
+func TestOutputAlphabetGame_MovesCausallyOrdered(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", 2)
+	correctTrace := game.CreateHonestActor(ctx, "sequencer")
+	claim := game.DisputeLastBlock(ctx)
+	// Invalid root claim of the alphabet game
+	claim = claim.Attack(ctx, common.Hash{0x01})
+
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	claim = claim.WaitForCounterClaim(ctx)
+	for !claim.IsMaxDepth(ctx) {
+		claim = correctTrace.AttackClaim(ctx, claim)
+		claim = claim.WaitForCounterClaim(ctx)
+	}
+	game.LogGameData(ctx)
+	game.AssertMovesCausallyOrdered(ctx)
+}
+
+func TestOutputAlphabetGame_BondSchedule(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xff})
+
+	// Required gas grows exponentially from 400k gas at the root to 300m gas at max depth, charged at 200 gwei.
+	// These are the whole gas amounts getRequiredBond charges with its WAD fixed-point math for the first depths of a
+	// game with a max depth of 18.
+	require.EqualValues(t, 18, game.MaxDepth(ctx), "Expected bonds are for a max depth of 18")
+	gasPrice := big.NewInt(200 * params.GWei)
+	var expected []*big.Int
+	for _, gas := range []int64{400_000, 577_810, 834_663, 1_205_693} {
+		expected = append(expected, new(big.Int).Mul(big.NewInt(gas), gasPrice))
+	}
+	game.AssertBondSchedule(ctx, expected)
+}
+
+func TestOutputAlphabetGame_ValidClaimTree(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xaa, 0xbb, 0xcc})
+	claim := game.DisputeLastBlock(ctx)
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	// Dishonest actor defends the invalid root claim
+	dishonest := game.CreateDishonestHelper(ctx, "sequencer", true)
+	dishonest.ExhaustDishonestClaims(ctx, claim)
+
+	sys.TimeTravelClock.AdvanceTime(game.MaxClockDuration(ctx))
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForGameStatus(ctx, disputegame.StatusChallengerWins)
+	game.LogGameData(ctx)
+	game.AssertValidClaimTree(ctx)
+}
+
+func TestOutputAlphabetGame_ChallengerLandsMovesUnderHighGas(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+	counter := game.RootClaim(ctx).WaitForCounterClaim(ctx)
+
+	initial := game.RaiseL1BaseFee(ctx, 0)
+	spiked := game.RaiseL1BaseFee(ctx, 10)
+	require.Greater(t, spiked.BaseFee.Cmp(initial.BaseFee), 0, "Base fee should have increased")
+
+	// Dishonest actor attacks the challenger's claim, which the challenger must counter despite the high base fee
+	counter.Attack(ctx, common.Hash{0x01})
+	game.AssertChallengerLandsMovesUnderHighGas(ctx, spiked)
+	game.LogGameData(ctx)
+}
+
+func TestOutputAlphabetGame_MoveEventIndexed(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	attack := game.RootClaim(ctx).Attack(ctx, common.Hash{0x01})
+	game.AssertMoveEventIndexed(ctx, attack.Index, game.Opts.From)
+}
+
+func TestOutputAlphabetGame_ResolutionGasScaling(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	disputeGameFactory.AssertResolutionGasScaling(ctx, "sequencer", 1)
+}
+
+func TestOutputAlphabetGame_ChallengerSkipsDeniedGame(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	denied, normal := disputeGameFactory.StartTwoGamesSameOutput(ctx, "sequencer", 3)
+
+	disputeGameFactory.StartChallengerWithDenylist(ctx, []common.Address{denied.Addr},
+		challenger.WithAlphabet(sys.RollupEndpoint("sequencer")),
+		challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	normal.RootClaim(ctx).WaitForCounterClaim(ctx)
+	disputeGameFactory.AssertChallengerSkipsDenied(ctx, denied.Addr)
+}
+
+func TestOutputAlphabetGame_MoveRejectedAfterClockExpiry(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	claim := game.RootClaim(ctx).Attack(ctx, common.Hash{0x01})
+	game.AssertMoveRejectedAfterClockExpiry(ctx, claim.Index)
+}
+
+func TestOutputAlphabetGame_ChallengerUpgradedMidGame(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	c := game.StartChallenger(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Progress the bisection a few steps before upgrading
+	claim := game.RootClaim(ctx).WaitForCounterClaim(ctx)
+	claim = claim.Attack(ctx, common.Hash{0x01})
+	claim = claim.WaitForCounterClaim(ctx)
+
+	c.UpgradeChallenger(ctx, challenger.WithPollInterval(500*time.Millisecond))
+	claim.Attack(ctx, common.Hash{0x02}).WaitForCounterClaim(ctx)
+
+	game.LogGameData(ctx)
+	game.AssertGameStillResolves(ctx)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+}
+
+func TestOutputAlphabetGame_CreatedEventsMatchGameCount(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	startBlock, err := l1Client.BlockNumber(ctx)
+	require.NoError(t, err)
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	disputeGameFactory.CreateManyGames(ctx, "sequencer", 1, 3)
+	disputeGameFactory.AssertEventCountMatchesGameCount(ctx, startBlock+1)
+	disputeGameFactory.AssertEventCountMatchesGameCount(ctx, 0)
+}
+
+func TestOutputAlphabetGame_ChallengerStartedAfterGame(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	game.StartChallengerAfterGame(ctx, "sequencer", "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	game.AssertChallengerCatchesUp(ctx)
+	require.Equal(t, disputegame.StatusChallengerWins, game.Status(ctx))
+}
+
+func TestOutputAlphabetGame_DeterministicResolution(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	disputeGameFactory.AssertDeterministicResolution(ctx, "abcdefgx", true, 3, challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+}
+
+func TestOutputAlphabetGame_AttackThenDefend(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 3, common.Hash{0xff})
+	m := metrics.MakeMoveMetrics("test", opmetrics.With(prometheus.NewRegistry()))
+	game.Metrics = &m
+
+	attackGas := game.Attack(ctx, 0, common.Hash{0x01})
+	defendGas := game.Defend(ctx, 1, common.Hash{0x02})
+	require.NotZero(t, attackGas, "Should report gas used by attack")
+	require.NotZero(t, defendGas, "Should report gas used by defend")
+	for moveType, gasUsed := range map[string]uint64{metrics.MoveTypeAttack: attackGas, metrics.MoveTypeDefend: defendGas} {
+		metric := &dto.Metric{}
+		require.NoError(t, m.ChallengerGasUsed.WithLabelValues(moveType).(prometheus.Metric).Write(metric))
+		require.EqualValuesf(t, 1, metric.GetHistogram().GetSampleCount(), "Should record one %v", moveType)
+		require.EqualValuesf(t, gasUsed, metric.GetHistogram().GetSampleSum(), "Should record gas used by %v", moveType)
+	}
+
+	game.WaitForClaimCount(ctx, 3)
+	require.Equal(t, common.Hash{0x01}, game.GetClaimValue(ctx, 1))
+	require.Equal(t, common.Hash{0x02}, game.GetClaimValue(ctx, 2))
+}
+
+func TestOutputAlphabetGame_WaitForResolvable(t *testing.T) {
+	op_e2e.InitParallel(t)
+	ctx := context.Background()
+	sys, l1Client := StartFaultDisputeSystem(t)
+	t.Cleanup(sys.Close)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	game := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", 1, common.Hash{0xff})
+	require.Equal(t, game.MaxClockDuration(ctx), disputeGameFactory.GameDuration())
+
+	sys.TimeTravelClock.AdvanceTime(disputeGameFactory.GameDuration())
+	require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+	game.WaitForResolvable(ctx)
+
+	game.ResolveClaim(ctx, 0)
+	game.Resolve(ctx)
+	require.Equal(t, disputegame.StatusDefenderWins, game.Status(ctx))
+}
@@ -44,7 +44,7 @@ type adminAPI struct {
 	dr driverClient
 }
 
-func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr driverClient, m metrics.RPCRequestMetricer, log log.Logger) *adminAPI {
 	return &adminAPI{
 		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
 		dr:             dr,
@@ -97,10 +97,10 @@ type nodeAPI struct {
 	dr     driverClient
 	safeDB SafeDBReader
 	log    log.Logger
-	m      metrics.RPCMetricer
+	m      metrics.RPCRequestMetricer
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m metrics.RPCRequestMetricer) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
@@ -1,104 +1,950 @@
 package metrics
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	RPCServerSubsystem = "rpc_server"
 	RPCClientSubsystem = "rpc_client"
+	DAClientSubsystem  = "da_client"
+	DAServerSubsystem  = "da_server"
 )
 
-type RPCMetricer interface {
+// defaultDurationBuckets are the buckets used by the duration histograms.
+// The client and DA request durations use them unless overridden with an
+// option to MakeRPCMetrics.
+var defaultDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// FastRPCBuckets are request duration buckets for low-latency subsystems whose
+// requests complete in well under the 5ms lowest default bucket. Pass them to
+// MakeRPCMetrics using WithClientDurationBuckets or WithDABuckets.
+var FastRPCBuckets = []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1}
+
+// otherMethodLabel is the method label recorded for methods that are not in
+// the allowlist supplied by WithMethodAllowlist.
+const otherMethodLabel = "other"
+
+type rpcMetricsConfig struct {
+	clientDurationBuckets []float64
+	daBuckets             []float64
+	allowedMethods        map[string]struct{}
+	classifyErrMessages   bool
+	backend               string
+	constLabels           prometheus.Labels
+	summaryMethods        map[string]struct{}
+	allocSampleRate       float64
+}
+
+// RPCMetricsOption customises the metrics created by MakeRPCMetrics.
+type RPCMetricsOption func(cfg *rpcMetricsConfig)
+
+// WithClientDurationBuckets overrides the buckets of the RPC client request
+// duration histogram.
+func WithClientDurationBuckets(buckets []float64) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.clientDurationBuckets = buckets
+	}
+}
+
+// WithDABuckets overrides the buckets of the DA client and server request
+// duration histograms. DA providers can be much slower to respond than regular RPC
+// endpoints so the defaults may be too small.
+func WithDABuckets(buckets []float64) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.daBuckets = buckets
+	}
+}
+
+// WithMethodAllowlist limits the values of the method label to the supplied
+// methods. Any other method is recorded as "other" to bound the number of
+// series created when callers send arbitrary method names. By default every
+// method is recorded as-is.
+func WithMethodAllowlist(methods []string) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.allowedMethods = make(map[string]struct{}, len(methods))
+		for _, method := range methods {
+			cfg.allowedMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithServerErrorMessageClassification enables classifying -32000 JSON-RPC
+// server errors by their message. Errors with a well known message, such as
+// "nonce too low", are recorded as rpc_-32000_nonce_too_low rather than
+// rpc_-32000. Unrecognised messages are still recorded as rpc_-32000 so the
+// number of error labels remains bounded.
+func WithServerErrorMessageClassification() RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.classifyErrMessages = true
+	}
+}
+
+// WithBackendLabel adds a constant backend label to the metrics so that RPC
+// clients connected to different backends can be told apart. Each backend
+// should use its own RPCMetrics instance, which may share a registry with the
+// others. By default no backend label is added.
+func WithBackendLabel(backend string) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.backend = backend
+	}
+}
+
+// WithConstLabels adds constant labels, such as the region or chain ID, to
+// every metric. The labels are the same for every sample so they don't
+// increase the number of series per metric.
+func WithConstLabels(labels prometheus.Labels) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.constLabels = labels
+	}
+}
+
+// WithDurationSummaries additionally records the RPC client request durations
+// of the supplied methods in a summary, which reports exact quantiles rather
+// than ones interpolated from histogram buckets. Summaries use much more
+// memory than histograms so should only be enabled for a few critical
+// methods. By default no summaries are recorded.
+func WithDurationSummaries(methods []string) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.summaryMethods = make(map[string]struct{}, len(methods))
+		for _, method := range methods {
+			cfg.summaryMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithAllocSampleRate sets the fraction, between 0 and 1, of calls to
+// TrackRPCServerAllocs that measure allocations. Measuring stops the world
+// so the rate should be kept small. By default no calls are measured.
+func WithAllocSampleRate(rate float64) RPCMetricsOption {
+	return func(cfg *rpcMetricsConfig) {
+		cfg.allocSampleRate = rate
+	}
+}
+
+// summaryObjectives are the quantiles, and their allowed error, reported by
+// the summaries enabled with WithDurationSummaries.
+var summaryObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
+// Error categories recorded in the error_category label of RPC client responses.
+// Requests the caller gave up on by cancelling their context are categorised
+// as client errors.
+const (
+	ErrorCategoryNone    = "none"
+	ErrorCategoryClient  = "client"
+	ErrorCategoryServer  = "server"
+	ErrorCategoryNetwork = "network"
+	ErrorCategoryTimeout = "timeout"
+	// ErrorCategoryUnknown is used for errors that can't be attributed to
+	// the client, the server or the network.
+	ErrorCategoryUnknown = "unknown"
+)
+
+// providerVersionPattern matches the client name and major and minor version
+// at the start of a web3_clientVersion string, e.g. Geth/v1.13.5-stable/linux.
+var providerVersionPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]{0,31})/v?(\d{1,8})\.(\d{1,8})(?:[^0-9]|$)`)
+
+const (
+	RPCOriginInternal = "internal"
+	RPCOriginExternal = "external"
+	// RPCOriginUnknown is recorded for any origin other than
+	// RPCOriginInternal or RPCOriginExternal.
+	RPCOriginUnknown = "unknown"
+)
+
+// RPCRequestMetricer records the requests served and sent over RPC. It is the
+// subset of RPCMetricer needed by RPC API implementations, so services that
+// only record requests don't need to implement every RPC metric.
+type RPCRequestMetricer interface {
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
 }
 
+type RPCMetricer interface {
+	RPCRequestMetricer
+	RecordRPCServerRequestWithError(method string) func(err error)
+	RecordRPCServerRequestOrigin(origin, method string) func()
+	RecordRPCServerResponse(method string, err error)
+	RecordRPCClientBatchPartial(method string, succeeded, failed int)
+	RecordRPCServerHOLBlocking(method string, blockedFor time.Duration)
+	RecordRPCServerConnMigration()
+	RecordRPCClientFanin(method string, count int)
+	RecordRPCClientSpeculative(method string, used bool)
+	RecordRPCServerTraceSampled(method string, sampled bool)
+	RecordRPCServerAllocs(method string, bytes int64)
+	RecordRPCServerShed(method string)
+	RecordRPCClientProviderVersion(provider, version string)
+	RecordRPCServerBufferPeak(method string, bytes int)
+	RecordRPCServerCompressionAlgo(algo, method string)
+	RecordRPCServerSlowConsumerDisconnect(method string)
+	RecordRPCServerIdempotencyKey(method string, hit bool)
+	RecordRPCClientFailoverLatency(method string, failedAttempts int, total time.Duration)
+	SetRPCServerAdmissionQueueDepth(method string, depth int)
+	RecordRPCServerCacheInvalidated(method string)
+	RecordRPCServerReplayWindowExpired(method string)
+	RecordRPCClientConnectFailure(provider string)
+	RecordRPCServerCoalesceWait(method string, d time.Duration)
+	RecordRPCServerDependencyDepth(method string, depth int)
+	RecordRPCServerPaginationPage(method string, page int)
+	RecordRPCClientStaleResponse(provider string, lag time.Duration)
+	RecordRPCClientBatch(methods []string) func(errs []error)
+	RecordRPCClientResponseSize(method string, bytes int)
+	RecordDAClientRequest(method string) func(err error)
+	RecordDAClientResponse(method string, err error)
+	RecordDABlobSize(method string, bytes int)
+	RecordDAClientRetry(method string)
+	RecordDAServerRequest(method string) func(err error)
+	RecordDAAvailabilityLag(method string, d time.Duration)
+	RecordRPCClientRequestStarted(method string) time.Time
+	RecordRPCClientRequestFinished(method string, start time.Time, err error)
+	RecordRPCSubscriptionStarted(method string)
+	RecordRPCSubscriptionEnded(method string, err error)
+	RecordRPCClientBatchElem(method string, elem rpc.BatchElem)
+	RecordRPCClientConnect(d time.Duration, err error)
+}
+
 // RPCMetrics tracks all the RPC metrics for the op-service RPC.
 type RPCMetrics struct {
-	RPCServerRequestsTotal          *prometheus.CounterVec
-	RPCServerRequestDurationSeconds *prometheus.HistogramVec
-	RPCClientRequestsTotal          *prometheus.CounterVec
-	RPCClientRequestDurationSeconds *prometheus.HistogramVec
-	RPCClientResponsesTotal         *prometheus.CounterVec
+	RPCServerRequestsTotal                *prometheus.CounterVec
+	RPCServerRequestDurationSeconds       *prometheus.HistogramVec
+	RPCServerResponsesTotal               *prometheus.CounterVec
+	RPCServerRequestsInFlight             *prometheus.GaugeVec
+	RPCServerRequestsByOriginTotal        *prometheus.CounterVec
+	RPCClientRequestsTotal                *prometheus.CounterVec
+	RPCClientRequestDurationSeconds       *prometheus.HistogramVec
+	RPCClientResponsesTotal               *prometheus.CounterVec
+	RPCClientBatchPartialTotal            *prometheus.CounterVec
+	RPCServerHOLBlockingSeconds           *prometheus.HistogramVec
+	RPCServerConnMigrationsTotal          *prometheus.CounterVec
+	RPCClientFaninHistogram               *prometheus.HistogramVec
+	RPCClientSpeculativeUsedTotal         *prometheus.CounterVec
+	RPCClientSpeculativeWastedTotal       *prometheus.CounterVec
+	RPCServerTraceSampledTotal            *prometheus.CounterVec
+	RPCServerAllocBytes                   *prometheus.HistogramVec
+	RPCServerShedTotal                    *prometheus.CounterVec
+	RPCClientProviderVersion              *prometheus.GaugeVec
+	RPCServerBufferPeakBytes              *prometheus.GaugeVec
+	RPCServerCompressionAlgoTotal         *prometheus.CounterVec
+	RPCServerSlowConsumerDisconnectsTotal *prometheus.CounterVec
+	RPCServerIdempotencyHitsTotal         *prometheus.CounterVec
+	RPCServerIdempotencyMissesTotal       *prometheus.CounterVec
+	RPCClientFailoverDurationSeconds      *prometheus.HistogramVec
+	RPCClientFailoverFailedAttemptsTotal  *prometheus.CounterVec
+	RPCServerAdmissionQueueDepth          *prometheus.GaugeVec
+	RPCServerCacheInvalidationsTotal      *prometheus.CounterVec
+	RPCServerReplayWindowExpiredTotal     *prometheus.CounterVec
+	RPCClientConnectFailuresTotal         *prometheus.CounterVec
+	RPCServerCoalesceWaitSeconds          *prometheus.HistogramVec
+	RPCServerDependencyDepth              *prometheus.HistogramVec
+	RPCServerPaginationPage               *prometheus.HistogramVec
+	RPCClientStalenessSeconds             *prometheus.HistogramVec
+	RPCClientBatchSize                    *prometheus.HistogramVec
+	RPCClientResponseSizeBytes            *prometheus.HistogramVec
+	DAClientRequestsTotal                 *prometheus.CounterVec
+	DAClientRequestDurationSeconds        *prometheus.HistogramVec
+	DAClientResponsesTotal                *prometheus.CounterVec
+	DAClientBlobSizeBytes                 *prometheus.HistogramVec
+	DAClientRetriesTotal                  *prometheus.CounterVec
+	DAServerRequestsTotal                 *prometheus.CounterVec
+	DAServerRequestDurationSeconds        *prometheus.HistogramVec
+	DAServerResponsesTotal                *prometheus.CounterVec
+	DAClientAvailabilityLagSeconds        *prometheus.HistogramVec
+	RPCActiveSubscriptions                *prometheus.GaugeVec
+	RPCSubscriptionErrorsTotal            *prometheus.CounterVec
+	RPCClientRequestDurationSummary       *prometheus.SummaryVec
+	RPCClientConnectDurationSeconds       *prometheus.HistogramVec
+	RPCClientConnectErrorsTotal           *prometheus.CounterVec
+
+	summaryMethods      map[string]struct{}
+	allocSampleRate     float64
+	bufferPeaks         *peakTracker
+	allowedMethods      map[string]struct{}
+	classifyErrMessages bool
 }
 
 // MakeRPCMetrics creates a new RPCMetrics instance with the given process name, and
 // namespace for the service.
-func MakeRPCMetrics(ns string, factory Factory) RPCMetrics {
+func MakeRPCMetrics(ns string, factory Factory, opts ...RPCMetricsOption) RPCMetrics {
+	cfg := rpcMetricsConfig{
+		clientDurationBuckets: defaultDurationBuckets,
+		daBuckets:             defaultDurationBuckets,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var constLabels prometheus.Labels
+	if len(cfg.constLabels) > 0 || cfg.backend != "" {
+		constLabels = make(prometheus.Labels, len(cfg.constLabels)+1)
+		for name, value := range cfg.constLabels {
+			constLabels[name] = value
+		}
+		if cfg.backend != "" {
+			constLabels["backend"] = cfg.backend
+		}
+	}
 	return RPCMetrics{
 		RPCServerRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: ns,
-			Subsystem: RPCServerSubsystem,
-			Name:      "requests_total",
-			Help:      "Total requests to the RPC server",
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "requests_total",
+			Help:        "Total requests to the RPC server",
 		}, []string{
 			"method",
 		}),
 		RPCServerRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
-			Namespace: ns,
-			Subsystem: RPCServerSubsystem,
-			Name:      "request_duration_seconds",
-			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
-			Help:      "Histogram of RPC server request durations",
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "request_duration_seconds",
+			Buckets:     defaultDurationBuckets,
+			Help:        "Histogram of RPC server request durations",
 		}, []string{
 			"method",
 		}),
+		RPCServerResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "responses_total",
+			Help:        "Total responses returned by the RPC server",
+		}, []string{
+			"method",
+			"error",
+		}),
+		RPCServerRequestsInFlight: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "requests_in_flight",
+			Help:        "Number of RPC server requests currently being handled",
+		}, []string{
+			"method",
+		}),
+		RPCServerRequestsByOriginTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "requests_by_origin_total",
+			Help:        "Total requests to the RPC server by request origin (internal, external or unknown)",
+		}, []string{
+			"origin",
+			"method",
+		}),
 		RPCClientRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: ns,
-			Subsystem: RPCClientSubsystem,
-			Name:      "requests_total",
-			Help:      "Total RPC requests initiated by the opnode's RPC client",
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "requests_total",
+			Help:        "Total RPC requests initiated by the opnode's RPC client",
 		}, []string{
 			"method",
 		}),
 		RPCClientRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
-			Namespace: ns,
-			Subsystem: RPCClientSubsystem,
-			Name:      "request_duration_seconds",
-			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
-			Help:      "Histogram of RPC client request durations",
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "request_duration_seconds",
+			Buckets:     cfg.clientDurationBuckets,
+			Help:        "Histogram of RPC client request durations",
 		}, []string{
 			"method",
 		}),
 		RPCClientResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
-			Namespace: ns,
-			Subsystem: RPCClientSubsystem,
-			Name:      "responses_total",
-			Help:      "Total RPC request responses received by the opnode's RPC client",
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "responses_total",
+			Help:        "Total RPC request responses received by the opnode's RPC client",
+		}, []string{
+			"method",
+			"error",
+			"error_category",
+		}),
+		RPCClientBatchPartialTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "batch_partial_total",
+			Help:        "Total batch elements that succeeded or failed within batches that partially failed",
+		}, []string{
+			"method",
+			"result",
+		}),
+		RPCServerHOLBlockingSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "hol_blocking_seconds",
+			Buckets:     defaultDurationBuckets,
+			Help:        "Histogram of time RPC server requests spent blocked behind earlier requests on the same connection",
+		}, []string{
+			"method",
+		}),
+		RPCServerConnMigrationsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "conn_migrations_total",
+			Help:        "Total HTTP/3 connections to the RPC server that migrated to a new network path",
+		}, []string{}),
+		RPCClientFaninHistogram: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "fanin",
+			Buckets:     []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+			Help:        "Histogram of the number of requests aggregated into a single upstream RPC call",
+		}, []string{
+			"method",
+		}),
+		RPCClientSpeculativeUsedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "speculative_used_total",
+			Help:        "Total speculative RPC client requests whose result was used",
+		}, []string{
+			"method",
+		}),
+		RPCClientSpeculativeWastedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "speculative_wasted_total",
+			Help:        "Total speculative RPC client requests that were cancelled or had their result discarded",
+		}, []string{
+			"method",
+		}),
+		RPCServerTraceSampledTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "trace_sampled_total",
+			Help:        "Total RPC server requests by whether they were sampled for distributed tracing",
+		}, []string{
+			"method",
+			"sampled",
+		}),
+		RPCServerAllocBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "alloc_bytes",
+			Buckets:     prometheus.ExponentialBuckets(1024, 4, 8),
+			Help:        "Histogram of bytes allocated by the process while serving sampled RPC server requests, including allocations made by other goroutines",
+		}, []string{
+			"method",
+		}),
+		RPCServerShedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "shed_total",
+			Help:        "Total RPC server requests proactively shed due to overload, excluding rate limit rejections",
+		}, []string{
+			"method",
+		}),
+		RPCClientProviderVersion: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "provider_version",
+			Help:        "Client version last reported by each upstream RPC provider, set to 1 for the current version",
+		}, []string{
+			"provider",
+			"version",
+		}),
+		RPCServerBufferPeakBytes: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "buffer_peak_bytes",
+			Help:        "Largest request body buffered by the RPC server",
+		}, []string{
+			"method",
+		}),
+		RPCServerCompressionAlgoTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "compression_algo_total",
+			Help:        "Total RPC server responses by negotiated compression algorithm",
+		}, []string{
+			"algo",
+			"method",
+		}),
+		RPCServerSlowConsumerDisconnectsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "slow_consumer_disconnects_total",
+			Help:        "Total websocket connections disconnected by the RPC server because the consumer could not keep up",
+		}, []string{
+			"method",
+		}),
+		RPCServerIdempotencyHitsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "idempotency_hits_total",
+			Help:        "Total RPC server requests with an idempotency key matching a previous request",
+		}, []string{
+			"method",
+		}),
+		RPCServerIdempotencyMissesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "idempotency_misses_total",
+			Help:        "Total RPC server requests with a previously unseen idempotency key",
+		}, []string{
+			"method",
+		}),
+		RPCClientFailoverDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "failover_duration_seconds",
+			Buckets:     defaultDurationBuckets,
+			Help:        "Histogram of total RPC client request durations, including failed attempts, for requests that failed over",
+		}, []string{
+			"method",
+		}),
+		RPCClientFailoverFailedAttemptsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "failover_failed_attempts_total",
+			Help:        "Total failed RPC client attempts that were retried against another provider",
+		}, []string{
+			"method",
+		}),
+		RPCServerAdmissionQueueDepth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "admission_queue_depth",
+			Help:        "Number of RPC server requests currently waiting for admission",
+		}, []string{
+			"method",
+		}),
+		RPCServerCacheInvalidationsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "cache_invalidations_total",
+			Help:        "Total cached RPC server responses invalidated because a new block was processed",
+		}, []string{
+			"method",
+		}),
+		RPCServerReplayWindowExpiredTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "replay_window_expired_total",
+			Help:        "Total replay protected RPC server requests rejected because their timestamp was outside the allowed window",
+		}, []string{
+			"method",
+		}),
+		RPCClientConnectFailuresTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "connect_failures_total",
+			Help:        "Total failures to establish a connection to an upstream RPC provider",
+		}, []string{
+			"provider",
+		}),
+		RPCServerCoalesceWaitSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "coalesce_wait_seconds",
+			Buckets:     []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+			Help:        "Histogram of time RPC server requests waited in the coalescing window before being served",
+		}, []string{
+			"method",
+		}),
+		RPCServerDependencyDepth: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "dependency_depth",
+			Buckets:     []float64{0, 1, 2, 3, 4, 5, 8, 16, 32},
+			Help:        "Histogram of the depth of internal RPC calls made while serving composite RPC server requests",
+		}, []string{
+			"method",
+		}),
+		RPCServerPaginationPage: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCServerSubsystem,
+			Name:        "pagination_page",
+			Buckets:     []float64{1, 2, 3, 5, 10, 20, 50, 100},
+			Help:        "Histogram of the page number requested from paginated RPC server methods",
+		}, []string{
+			"method",
+		}),
+		RPCClientStalenessSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "staleness_seconds",
+			Buckets:     []float64{1, 2, 4, 8, 12, 24, 60, 120, 300, 600},
+			Help:        "Histogram of how far behind the expected chain head responses from an upstream provider were",
+		}, []string{
+			"provider",
+		}),
+		RPCClientBatchSize: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "batch_size",
+			Buckets:     []float64{1, 2, 5, 10, 20, 50, 100, 200, 500},
+			Help:        "Histogram of the number of requests in batches sent by the RPC client",
+		}, []string{}),
+		RPCClientResponseSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "response_size_bytes",
+			// 256 B to 16 MiB
+			Buckets: prometheus.ExponentialBuckets(256, 4, 9),
+			Help:    "Histogram of RPC client response payload sizes",
+		}, []string{
+			"method",
+		}),
+		DAClientRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "requests_total",
+			Help:        "Total requests initiated by the DA client",
+		}, []string{
+			"method",
+		}),
+		DAClientRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "request_duration_seconds",
+			Buckets:     cfg.daBuckets,
+			Help:        "Histogram of DA client request durations",
+		}, []string{
+			"method",
+		}),
+		DAClientResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "responses_total",
+			Help:        "Total responses received by the DA client",
+		}, []string{
+			"method",
+			"error",
+		}),
+		DAClientBlobSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "blob_size_bytes",
+			// 1 KiB to 2 MiB
+			Buckets: prometheus.ExponentialBuckets(1024, 2, 12),
+			Help:    "Histogram of the size of blobs handled by the DA client",
+		}, []string{
+			"method",
+		}),
+		DAClientRetriesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "retries_total",
+			Help:        "Total DA client requests retried after a transient failure",
+		}, []string{
+			"method",
+		}),
+		DAServerRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAServerSubsystem,
+			Name:        "requests_total",
+			Help:        "Total requests to the DA server",
+		}, []string{
+			"method",
+		}),
+		DAServerRequestDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAServerSubsystem,
+			Name:        "request_duration_seconds",
+			Buckets:     cfg.daBuckets,
+			Help:        "Histogram of DA server request durations",
+		}, []string{
+			"method",
+		}),
+		DAServerResponsesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAServerSubsystem,
+			Name:        "responses_total",
+			Help:        "Total responses returned by the DA server",
 		}, []string{
 			"method",
 			"error",
 		}),
+		DAClientAvailabilityLagSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   DAClientSubsystem,
+			Name:        "availability_lag_seconds",
+			Buckets:     []float64{1, 2, 5, 10, 30, 60, 120, 300, 600},
+			Help:        "Histogram of the time between data being posted to the DA provider and it becoming retrievable",
+		}, []string{
+			"method",
+		}),
+		RPCActiveSubscriptions: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "active_subscriptions",
+			Help:        "Number of currently active RPC client subscriptions",
+		}, []string{
+			"method",
+		}),
+		RPCSubscriptionErrorsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "subscription_errors_total",
+			Help:        "Total RPC client subscriptions that ended with an error",
+		}, []string{
+			"method",
+			"error",
+		}),
+		summaryMethods: cfg.summaryMethods,
+		RPCClientRequestDurationSummary: newDurationSummary(factory, prometheus.SummaryOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "request_duration_summary_seconds",
+			Objectives:  summaryObjectives,
+			Help:        "Summary of RPC client request durations for selected methods",
+		}, cfg.summaryMethods),
+		RPCClientConnectDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "connect_duration_seconds",
+			Buckets:     defaultDurationBuckets,
+			Help:        "Histogram of the time taken to establish connections, including any TLS handshake, to RPC providers",
+		}, []string{}),
+		RPCClientConnectErrorsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   ns,
+			ConstLabels: constLabels,
+			Subsystem:   RPCClientSubsystem,
+			Name:        "connect_errors_total",
+			Help:        "Total errors establishing connections to RPC providers",
+		}, []string{
+			"error",
+		}),
+		allocSampleRate:     cfg.allocSampleRate,
+		bufferPeaks:         newPeakTracker(),
+		allowedMethods:      cfg.allowedMethods,
+		classifyErrMessages: cfg.classifyErrMessages,
+	}
+}
+
+// newDurationSummary creates the request duration summary if summaries are
+// enabled for any methods, and otherwise returns nil so no summary is
+// registered.
+func newDurationSummary(factory Factory, opts prometheus.SummaryOpts, methods map[string]struct{}) *prometheus.SummaryVec {
+	if len(methods) == 0 {
+		return nil
 	}
+	return factory.NewSummaryVec(opts, []string{
+		"method",
+	})
+}
+
+// normalizeMethod returns the method label to record for method. If an
+// allowlist was configured, methods not in it are collapsed to "other".
+func (m *RPCMetrics) normalizeMethod(method string) string {
+	if m.allowedMethods == nil {
+		return method
+	}
+	if _, ok := m.allowedMethods[method]; !ok {
+		return otherMethodLabel
+	}
+	return method
+}
+
+// Reset clears the values recorded by every collector, including the
+// in-flight and peak gauges, so tests sharing a registry can start from a
+// clean slate. The collectors remain registered.
+func (m *RPCMetrics) Reset() {
+	m.RPCServerRequestsTotal.Reset()
+	m.RPCServerRequestDurationSeconds.Reset()
+	m.RPCServerResponsesTotal.Reset()
+	m.RPCServerRequestsInFlight.Reset()
+	m.RPCServerRequestsByOriginTotal.Reset()
+	m.RPCClientRequestsTotal.Reset()
+	m.RPCClientRequestDurationSeconds.Reset()
+	m.RPCClientResponsesTotal.Reset()
+	m.RPCClientBatchPartialTotal.Reset()
+	m.RPCServerHOLBlockingSeconds.Reset()
+	m.RPCServerConnMigrationsTotal.Reset()
+	m.RPCClientFaninHistogram.Reset()
+	m.RPCClientSpeculativeUsedTotal.Reset()
+	m.RPCClientSpeculativeWastedTotal.Reset()
+	m.RPCServerTraceSampledTotal.Reset()
+	m.RPCServerAllocBytes.Reset()
+	m.RPCServerShedTotal.Reset()
+	m.RPCClientProviderVersion.Reset()
+	m.RPCServerBufferPeakBytes.Reset()
+	m.RPCServerCompressionAlgoTotal.Reset()
+	m.RPCServerSlowConsumerDisconnectsTotal.Reset()
+	m.RPCServerIdempotencyHitsTotal.Reset()
+	m.RPCServerIdempotencyMissesTotal.Reset()
+	m.RPCClientFailoverDurationSeconds.Reset()
+	m.RPCClientFailoverFailedAttemptsTotal.Reset()
+	m.RPCServerAdmissionQueueDepth.Reset()
+	m.RPCServerCacheInvalidationsTotal.Reset()
+	m.RPCServerReplayWindowExpiredTotal.Reset()
+	m.RPCClientConnectFailuresTotal.Reset()
+	m.RPCServerCoalesceWaitSeconds.Reset()
+	m.RPCServerDependencyDepth.Reset()
+	m.RPCServerPaginationPage.Reset()
+	m.RPCClientStalenessSeconds.Reset()
+	m.RPCClientBatchSize.Reset()
+	m.RPCClientResponseSizeBytes.Reset()
+	m.DAClientRequestsTotal.Reset()
+	m.DAClientRequestDurationSeconds.Reset()
+	m.DAClientResponsesTotal.Reset()
+	m.DAClientBlobSizeBytes.Reset()
+	m.DAClientRetriesTotal.Reset()
+	m.DAServerRequestsTotal.Reset()
+	m.DAServerRequestDurationSeconds.Reset()
+	m.DAServerResponsesTotal.Reset()
+	m.DAClientAvailabilityLagSeconds.Reset()
+	m.RPCActiveSubscriptions.Reset()
+	m.RPCSubscriptionErrorsTotal.Reset()
+	m.RPCClientConnectDurationSeconds.Reset()
+	m.RPCClientConnectErrorsTotal.Reset()
+	if m.RPCClientRequestDurationSummary != nil {
+		m.RPCClientRequestDurationSummary.Reset()
+	}
+	m.bufferPeaks.reset()
 }
 
 // RecordRPCServerRequest is a helper method to record an incoming RPC
 // call to the opnode's RPC server. It bumps the requests metric,
-// and tracks how long it takes to serve a response.
+// tracks how long it takes to serve a response and counts the request
+// as in flight until the returned function is called.
+// Callers should defer the returned function so the in-flight gauge is
+// decremented even if the handler panics:
+//
+//	defer m.RecordRPCServerRequest(method)()
 func (m *RPCMetrics) RecordRPCServerRequest(method string) func() {
+	return m.recordRPCServerRequest(m.normalizeMethod(method))
+}
+
+// RecordRPCServerRequestWithError records an incoming RPC call in the same
+// way as RecordRPCServerRequest. The returned function must be called with
+// the error returned by the handler, which is recorded as the response, see
+// RecordRPCServerResponse.
+func (m *RPCMetrics) RecordRPCServerRequestWithError(method string) func(err error) {
+	method = m.normalizeMethod(method)
+	done := m.recordRPCServerRequest(method)
+	return func(err error) {
+		defer done()
+		m.RPCServerResponsesTotal.WithLabelValues(method, ErrorLabel(err)).Inc()
+	}
+}
+
+// recordRPCServerRequest records an incoming RPC call for a method that has
+// already been normalized.
+func (m *RPCMetrics) recordRPCServerRequest(method string) func() {
 	m.RPCServerRequestsTotal.WithLabelValues(method).Inc()
+	inFlight := m.RPCServerRequestsInFlight.WithLabelValues(method)
+	inFlight.Inc()
 	timer := prometheus.NewTimer(m.RPCServerRequestDurationSeconds.WithLabelValues(method))
 	return func() {
+		defer inFlight.Dec()
 		timer.ObserveDuration()
 	}
 }
 
+// RecordRPCServerResponse records the outcome of a call handled by the RPC
+// server. The error is converted into a label in the same way as for
+// RecordRPCClientResponse.
+func (m *RPCMetrics) RecordRPCServerResponse(method string, err error) {
+	method = m.normalizeMethod(method)
+	m.RPCServerResponsesTotal.WithLabelValues(method, ErrorLabel(err)).Inc()
+}
+
+// RecordRPCServerRequestOrigin records an incoming RPC call in the same way as
+// RecordRPCServerRequest, and additionally counts it against the origin of the
+// request, either RPCOriginInternal or RPCOriginExternal. Any other origin is
+// recorded as RPCOriginUnknown.
+func (m *RPCMetrics) RecordRPCServerRequestOrigin(origin, method string) func() {
+	method = m.normalizeMethod(method)
+	switch origin {
+	case RPCOriginInternal, RPCOriginExternal:
+	default:
+		origin = RPCOriginUnknown
+	}
+	m.RPCServerRequestsByOriginTotal.WithLabelValues(origin, method).Inc()
+	return m.recordRPCServerRequest(method)
+}
+
 // RecordRPCClientRequest is a helper method to record an RPC client
 // request. It bumps the requests metric, tracks the response
 // duration, and records the response's error code.
 func (m *RPCMetrics) RecordRPCClientRequest(method string) func(err error) {
-	m.RPCClientRequestsTotal.WithLabelValues(method).Inc()
-	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(method))
+	start := m.RecordRPCClientRequestStarted(method)
 	return func(err error) {
-		m.RecordRPCClientResponse(method, err)
-		timer.ObserveDuration()
+		m.RecordRPCClientRequestFinished(method, start, err)
+	}
+}
+
+// RecordRPCClientRequestStarted records an RPC request sent by the client and
+// returns the time it started. Pass the returned time to
+// RecordRPCClientRequestFinished once the response is received. Unlike
+// RecordRPCClientRequest, the two calls can be made from different goroutines.
+func (m *RPCMetrics) RecordRPCClientRequestStarted(method string) time.Time {
+	method = m.normalizeMethod(method)
+	m.RPCClientRequestsTotal.WithLabelValues(method).Inc()
+	return time.Now()
+}
+
+// RecordRPCClientRequestFinished records the response to an RPC request
+// started at start, as returned by RecordRPCClientRequestStarted, and the
+// duration of the request. See RecordRPCClientResponse for how err is recorded.
+func (m *RPCMetrics) RecordRPCClientRequestFinished(method string, start time.Time, err error) {
+	method = m.normalizeMethod(method)
+	m.RecordRPCClientResponse(method, err)
+	duration := time.Since(start).Seconds()
+	m.RPCClientRequestDurationSeconds.WithLabelValues(method).Observe(duration)
+	if _, ok := m.summaryMethods[method]; ok {
+		m.RPCClientRequestDurationSummary.WithLabelValues(method).Observe(duration)
 	}
 }
 
@@ -106,24 +952,557 @@ func (m *RPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 // convert the passed-in error into something metrics friendly.
 // Nil errors get converted into <nil>, RPC errors are converted
 // into rpc_<error code>, HTTP errors are converted into
-// http_<status code>, and everything else is converted into
-// <unknown>.
+// http_<status code>, timeouts are converted into <timeout>,
+// context cancellations are converted into <canceled> and
+// everything else is converted into <unknown>. Each error is
+// also assigned a broader category,
+// see ErrorCategory constants. If enabled with
+// WithServerErrorMessageClassification, -32000 errors with a known
+// message are converted into rpc_-32000_<message>.
 func (m *RPCMetrics) RecordRPCClientResponse(method string, err error) {
-	var errStr string
+	method = m.normalizeMethod(method)
+	errStr, category := classifyError(err)
+	if m.classifyErrMessages {
+		errStr = classifyServerErrorMessage(err, errStr)
+	}
+	m.RPCClientResponsesTotal.WithLabelValues(method, errStr, category).Inc()
+}
+
+// ErrorLabel converts err into a metrics friendly error label. It is the same
+// label recorded by RecordRPCClientResponse, so services that build their own
+// counters can use it to keep error labels consistent.
+// See RecordRPCClientResponse for the possible values.
+func ErrorLabel(err error) string {
+	label, _ := classifyError(err)
+	return label
+}
+
+// classifyError returns the error and error_category labels for err.
+func classifyError(err error) (string, string) {
 	var rpcErr rpc.Error
 	var httpErr rpc.HTTPError
+	var netErr net.Error
 	if err == nil {
-		errStr = "<nil>"
+		return "<nil>", ErrorCategoryNone
 	} else if errors.As(err, &rpcErr) {
-		errStr = fmt.Sprintf("rpc_%d", rpcErr.ErrorCode())
+		return fmt.Sprintf("rpc_%d", rpcErr.ErrorCode()), rpcErrorCategory(rpcErr.ErrorCode())
 	} else if errors.As(err, &httpErr) {
-		errStr = fmt.Sprintf("http_%d", httpErr.StatusCode)
+		return fmt.Sprintf("http_%d", httpErr.StatusCode), httpErrorCategory(httpErr.StatusCode)
 	} else if errors.Is(err, ethereum.NotFound) {
-		errStr = "<not found>"
+		return "<not found>", ErrorCategoryClient
+	} else if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return "<timeout>", ErrorCategoryTimeout
+	} else if errors.Is(err, context.Canceled) {
+		return "<canceled>", ErrorCategoryClient
+	} else if errors.As(err, &netErr) {
+		return "<unknown>", ErrorCategoryNetwork
 	} else {
-		errStr = "<unknown>"
+		return "<unknown>", ErrorCategoryUnknown
+	}
+}
+
+// knownServerErrorMessages are the -32000 error messages returned by geth
+// that are given their own error label when message classification is
+// enabled. Messages are matched in order so more specific messages must come
+// before any message they contain.
+var knownServerErrorMessages = []string{
+	"nonce too low",
+	"nonce too high",
+	"insufficient funds",
+	"already known",
+	"replacement transaction underpriced",
+	"transaction underpriced",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"execution reverted",
+	"header not found",
+}
+
+// classifyServerErrorMessage returns the error label for a -32000 error with a
+// known message, or label unchanged for any other error.
+func classifyServerErrorMessage(err error, label string) string {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != -32000 {
+		return label
+	}
+	msg := strings.ToLower(rpcErr.Error())
+	for _, known := range knownServerErrorMessages {
+		if strings.Contains(msg, known) {
+			return label + "_" + strings.ReplaceAll(known, " ", "_")
+		}
+	}
+	return label
+}
+
+// rpcErrorCategory categorises JSON-RPC error codes. The standard codes for
+// malformed requests are client errors and everything else, including the
+// -32000 to -32099 implementation defined range, is a server error.
+func rpcErrorCategory(code int) string {
+	switch code {
+	case -32700, -32600, -32601, -32602:
+		return ErrorCategoryClient
+	default:
+		return ErrorCategoryServer
+	}
+}
+
+func httpErrorCategory(status int) string {
+	switch {
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return ErrorCategoryTimeout
+	case status >= 400 && status < 500:
+		return ErrorCategoryClient
+	default:
+		return ErrorCategoryServer
+	}
+}
+
+// RecordRPCClientBatchPartial records the split of succeeded and failed
+// elements within a batch that only partially succeeded.
+func (m *RPCMetrics) RecordRPCClientBatchPartial(method string, succeeded, failed int) {
+	method = m.normalizeMethod(method)
+	m.RPCClientBatchPartialTotal.WithLabelValues(method, "success").Add(float64(succeeded))
+	m.RPCClientBatchPartialTotal.WithLabelValues(method, "failure").Add(float64(failed))
+}
+
+// RecordRPCServerHOLBlocking records that a request for method was blocked
+// for the given duration behind an earlier request on the same connection,
+// e.g. due to HTTP/1.1 pipelining or websocket message ordering.
+func (m *RPCMetrics) RecordRPCServerHOLBlocking(method string, blockedFor time.Duration) {
+	method = m.normalizeMethod(method)
+	m.RPCServerHOLBlockingSeconds.WithLabelValues(method).Observe(blockedFor.Seconds())
+}
+
+// RecordRPCServerConnMigration records a QUIC connection migration on an
+// HTTP/3 RPC server endpoint.
+func (m *RPCMetrics) RecordRPCServerConnMigration() {
+	m.RPCServerConnMigrationsTotal.WithLabelValues().Inc()
+}
+
+// RecordRPCClientFanin records the number of requests that were aggregated
+// into a single upstream call to method.
+func (m *RPCMetrics) RecordRPCClientFanin(method string, count int) {
+	method = m.normalizeMethod(method)
+	m.RPCClientFaninHistogram.WithLabelValues(method).Observe(float64(count))
+}
+
+// RecordRPCClientSpeculative records the outcome of a speculatively issued
+// request. used should be true if the request's result was used and false if
+// it lost the race and was cancelled or discarded.
+func (m *RPCMetrics) RecordRPCClientSpeculative(method string, used bool) {
+	method = m.normalizeMethod(method)
+	if used {
+		m.RPCClientSpeculativeUsedTotal.WithLabelValues(method).Inc()
+	} else {
+		m.RPCClientSpeculativeWastedTotal.WithLabelValues(method).Inc()
+	}
+}
+
+// RecordRPCServerTraceSampled records whether a request to method was
+// sampled for distributed tracing.
+func (m *RPCMetrics) RecordRPCServerTraceSampled(method string, sampled bool) {
+	method = m.normalizeMethod(method)
+	if sampled {
+		m.RPCServerTraceSampledTotal.WithLabelValues(method, "true").Inc()
+	} else {
+		m.RPCServerTraceSampledTotal.WithLabelValues(method, "false").Inc()
+	}
+}
+
+// RecordRPCServerAllocs records the number of bytes allocated while serving
+// a request to method.
+func (m *RPCMetrics) RecordRPCServerAllocs(method string, bytes int64) {
+	method = m.normalizeMethod(method)
+	m.RPCServerAllocBytes.WithLabelValues(method).Observe(float64(bytes))
+}
+
+// TrackRPCServerAllocs measures the bytes allocated between the call and
+// invoking the returned function, and records them with
+// RecordRPCServerAllocs. The measurement uses runtime.ReadMemStats, which
+// stops the world, so only the fraction of calls set by WithAllocSampleRate
+// are measured and a no-op function is returned for the rest. Allocations
+// made by concurrent goroutines are included in the measurement.
+func (m *RPCMetrics) TrackRPCServerAllocs(method string) func() {
+	if m.allocSampleRate <= 0 || (m.allocSampleRate < 1 && rand.Float64() >= m.allocSampleRate) {
+		return func() {}
+	}
+	method = m.normalizeMethod(method)
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	return func() {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		m.RecordRPCServerAllocs(method, int64(after.TotalAlloc-before.TotalAlloc))
+	}
+}
+
+// RecordRPCServerShed records a request to method that was proactively shed
+// because the server was overloaded. Requests rejected by hard rate limits
+// should not be recorded here.
+func (m *RPCMetrics) RecordRPCServerShed(method string) {
+	method = m.normalizeMethod(method)
+	m.RPCServerShedTotal.WithLabelValues(method).Inc()
+}
+
+// RecordRPCClientProviderVersion records the client version reported by an
+// upstream provider, e.g. the result of web3_clientVersion. Only the latest
+// version is kept for each provider. To keep the label bounded, the version is
+// normalised to the client name and major and minor version, so
+// Geth/v1.13.5-stable-916d6a44/linux is recorded as Geth/v1.13. Versions that
+// can't be parsed are recorded as "<unknown>".
+func (m *RPCMetrics) RecordRPCClientProviderVersion(provider, version string) {
+	m.RPCClientProviderVersion.DeletePartialMatch(prometheus.Labels{"provider": provider})
+	m.RPCClientProviderVersion.WithLabelValues(provider, normalizeProviderVersion(version)).Set(1)
+}
+
+func normalizeProviderVersion(version string) string {
+	match := providerVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%s/v%s.%s", match[1], match[2], match[3])
+}
+
+// RecordRPCServerBufferPeak records the size of a request body buffered by
+// the server for method. The gauge only ever increases, so it reflects the
+// largest request buffered so far.
+func (m *RPCMetrics) RecordRPCServerBufferPeak(method string, bytes int) {
+	method = m.normalizeMethod(method)
+	if peak, ok := m.bufferPeaks.update(method, bytes); ok {
+		m.RPCServerBufferPeakBytes.WithLabelValues(method).Set(float64(peak))
+	}
+}
+
+// peakTracker tracks the highest value seen for each key.
+type peakTracker struct {
+	mu    sync.Mutex
+	peaks map[string]int
+}
+
+func newPeakTracker() *peakTracker {
+	return &peakTracker{peaks: make(map[string]int)}
+}
+
+// update records v for key and returns the new peak and true if v exceeds
+// the previous peak.
+func (p *peakTracker) update(key string, v int) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if prev, ok := p.peaks[key]; ok && prev >= v {
+		return prev, false
+	}
+	p.peaks[key] = v
+	return v, true
+}
+
+// reset forgets every recorded peak.
+func (p *peakTracker) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.peaks = make(map[string]int)
+}
+
+// RecordRPCServerCompressionAlgo records the compression algorithm
+// negotiated for a response to method. Algorithms other than gzip, br, zstd
+// and deflate are recorded as "other", and uncompressed responses should be
+// recorded with an empty algo which is recorded as "none".
+func (m *RPCMetrics) RecordRPCServerCompressionAlgo(algo, method string) {
+	method = m.normalizeMethod(method)
+	switch algo {
+	case "":
+		algo = "none"
+	case "gzip", "br", "zstd", "deflate":
+	default:
+		algo = "other"
+	}
+	m.RPCServerCompressionAlgoTotal.WithLabelValues(algo, method).Inc()
+}
+
+// RecordRPCServerSlowConsumerDisconnect records that a websocket consumer
+// was forcibly disconnected because it fell too far behind while receiving
+// responses or notifications for method.
+func (m *RPCMetrics) RecordRPCServerSlowConsumerDisconnect(method string) {
+	method = m.normalizeMethod(method)
+	m.RPCServerSlowConsumerDisconnectsTotal.WithLabelValues(method).Inc()
+}
+
+// RecordRPCServerIdempotencyKey records a request to method that supplied an
+// idempotency key. hit should be true if the key matched a previous request
+// and the original result was returned instead of repeating the request.
+func (m *RPCMetrics) RecordRPCServerIdempotencyKey(method string, hit bool) {
+	method = m.normalizeMethod(method)
+	if hit {
+		m.RPCServerIdempotencyHitsTotal.WithLabelValues(method).Inc()
+	} else {
+		m.RPCServerIdempotencyMissesTotal.WithLabelValues(method).Inc()
+	}
+}
+
+// RecordRPCClientFailoverLatency records a request to method that only
+// succeeded after failing over to another provider. failedAttempts is the
+// number of attempts that failed before the successful one, and total is the
+// duration from the first attempt until the successful response.
+func (m *RPCMetrics) RecordRPCClientFailoverLatency(method string, failedAttempts int, total time.Duration) {
+	method = m.normalizeMethod(method)
+	m.RPCClientFailoverFailedAttemptsTotal.WithLabelValues(method).Add(float64(failedAttempts))
+	m.RPCClientFailoverDurationSeconds.WithLabelValues(method).Observe(total.Seconds())
+}
+
+// SetRPCServerAdmissionQueueDepth sets the number of requests to method
+// currently queued waiting for admission control to let them through.
+func (m *RPCMetrics) SetRPCServerAdmissionQueueDepth(method string, depth int) {
+	method = m.normalizeMethod(method)
+	m.RPCServerAdmissionQueueDepth.WithLabelValues(method).Set(float64(depth))
+}
+
+// RecordRPCServerCacheInvalidated records that a cached response for method
+// was invalidated because a new block made it stale.
+func (m *RPCMetrics) RecordRPCServerCacheInvalidated(method string) {
+	method = m.normalizeMethod(method)
+	m.RPCServerCacheInvalidationsTotal.WithLabelValues(method).Inc()
+}
+
+// RecordRPCServerReplayWindowExpired records a replay protected request to
+// method that was rejected because its timestamp fell outside the allowed
+// window. A high rate usually indicates clock skew between client and server.
+func (m *RPCMetrics) RecordRPCServerReplayWindowExpired(method string) {
+	method = m.normalizeMethod(method)
+	m.RPCServerReplayWindowExpiredTotal.WithLabelValues(method).Inc()
+}
+
+// RecordRPCClientConnectFailure records a failure to establish a connection
+// to provider. These are tracked separately to response errors so network
+// reachability problems can be distinguished from failing requests.
+func (m *RPCMetrics) RecordRPCClientConnectFailure(provider string) {
+	m.RPCClientConnectFailuresTotal.WithLabelValues(provider).Inc()
+}
+
+// RecordRPCClientConnect records the time taken to establish a connection,
+// including any TLS handshake, to an RPC provider. This is separate from the
+// duration of the requests sent over the connection. A non-nil err is also
+// counted, labelled using ErrorLabel.
+func (m *RPCMetrics) RecordRPCClientConnect(d time.Duration, err error) {
+	m.RPCClientConnectDurationSeconds.WithLabelValues().Observe(d.Seconds())
+	if err != nil {
+		m.RPCClientConnectErrorsTotal.WithLabelValues(ErrorLabel(err)).Inc()
+	}
+}
+
+// RecordRPCServerCoalesceWait records how long a request to method waited
+// in the coalescing window before being combined with other requests.
+func (m *RPCMetrics) RecordRPCServerCoalesceWait(method string, d time.Duration) {
+	method = m.normalizeMethod(method)
+	m.RPCServerCoalesceWaitSeconds.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// RecordRPCServerDependencyDepth records the maximum depth of nested
+// internal method calls made while serving a request to method. A request
+// that doesn't call any other methods has a depth of 0.
+func (m *RPCMetrics) RecordRPCServerDependencyDepth(method string, depth int) {
+	method = m.normalizeMethod(method)
+	m.RPCServerDependencyDepth.WithLabelValues(method).Observe(float64(depth))
+}
+
+// RecordRPCServerPaginationPage records the page requested by a call to a
+// paginated method, starting from page 1 for requests without a cursor.
+func (m *RPCMetrics) RecordRPCServerPaginationPage(method string, page int) {
+	method = m.normalizeMethod(method)
+	m.RPCServerPaginationPage.WithLabelValues(method).Observe(float64(page))
+}
+
+// RecordRPCClientStaleResponse records that provider returned data lagging
+// behind the expected chain head by lag.
+func (m *RPCMetrics) RecordRPCClientStaleResponse(provider string, lag time.Duration) {
+	m.RPCClientStalenessSeconds.WithLabelValues(provider).Observe(lag.Seconds())
+}
+
+// RecordRPCClientBatch records a batch of RPC requests, one for each entry in
+// methods, and returns a function that records the outcome of each request
+// once the batch completes. The error at index i of errs is attributed to
+// methods[i]. Requests without a corresponding entry in errs, including when
+// errs is nil, are recorded as successful.
+func (m *RPCMetrics) RecordRPCClientBatch(methods []string) func(errs []error) {
+	m.RPCClientBatchSize.WithLabelValues().Observe(float64(len(methods)))
+	for _, method := range methods {
+		m.RPCClientRequestsTotal.WithLabelValues(m.normalizeMethod(method)).Inc()
+	}
+	return func(errs []error) {
+		for i, method := range methods {
+			var err error
+			if i < len(errs) {
+				err = errs[i]
+			}
+			m.RecordRPCClientResponse(method, err)
+		}
+	}
+}
+
+// RecordRPCClientBatchElem records the response to a single element of a
+// batch call. Errors for individual elements are reported in elem.Error even
+// when the batch itself succeeded, and are recorded in the same way as
+// RecordRPCClientResponse.
+func (m *RPCMetrics) RecordRPCClientBatchElem(method string, elem rpc.BatchElem) {
+	m.RecordRPCClientResponse(method, elem.Error)
+}
+
+// RecordRPCClientResponseSize records the size in bytes of the response body
+// received for an RPC client call. It is independent of the request timer
+// returned by RecordRPCClientRequest so callers that already know the body
+// length can report it directly.
+func (m *RPCMetrics) RecordRPCClientResponseSize(method string, bytes int) {
+	method = m.normalizeMethod(method)
+	m.RPCClientResponseSizeBytes.WithLabelValues(method).Observe(float64(bytes))
+}
+
+// RecordDAClientRequest is a helper method to record a request made by the
+// DA client. It bumps the requests metric, tracks the request duration and
+// returns a function that records the response once the request completes.
+func (m *RPCMetrics) RecordDAClientRequest(method string) func(err error) {
+	method = m.normalizeMethod(method)
+	m.DAClientRequestsTotal.WithLabelValues(method).Inc()
+	timer := prometheus.NewTimer(m.DAClientRequestDurationSeconds.WithLabelValues(method))
+	return func(err error) {
+		m.RecordDAClientResponse(method, err)
+		timer.ObserveDuration()
+	}
+}
+
+// RecordDAClientResponse records a response received by the DA client.
+// Nil errors get converted into <nil>, gRPC status errors into
+// grpc_<status code>, HTTP errors from non-gRPC backends into
+// http_<status code>, timeouts into <timeout>, context cancellations into
+// <canceled> and everything else into <unknown>.
+func (m *RPCMetrics) RecordDAClientResponse(method string, err error) {
+	method = m.normalizeMethod(method)
+	m.DAClientResponsesTotal.WithLabelValues(method, daErrLabel(err)).Inc()
+}
+
+// httpStatusError is implemented by errors from HTTP DA backends that report
+// the HTTP status code of the failed response.
+type httpStatusError interface {
+	error
+	StatusCode() int
+}
+
+// daErrLabel returns the error label for an error returned by the DA client.
+// gRPC status errors are recognised using grpc's own status package. This is
+// the package go-da's proxy client uses to build the errors it returns, and
+// grpc is already in the build through go-da, so it adds no new module. There
+// is no gogo status mapping in this repository to reuse instead.
+func daErrLabel(err error) string {
+	var httpErr rpc.HTTPError
+	var statusErr httpStatusError
+	if err == nil {
+		return "<nil>"
+	} else if st, ok := status.FromError(err); ok {
+		return "grpc_" + st.Code().String()
+	} else if errors.As(err, &httpErr) {
+		return fmt.Sprintf("http_%d", httpErr.StatusCode)
+	} else if errors.As(err, &statusErr) {
+		return fmt.Sprintf("http_%d", statusErr.StatusCode())
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		return "<timeout>"
+	} else if errors.Is(err, context.Canceled) {
+		return "<canceled>"
+	}
+	return "<unknown>"
+}
+
+// RecordDABlobSize records the size in bytes of a blob submitted to or
+// retrieved from the DA provider.
+func (m *RPCMetrics) RecordDABlobSize(method string, bytes int) {
+	method = m.normalizeMethod(method)
+	m.DAClientBlobSizeBytes.WithLabelValues(method).Observe(float64(bytes))
+}
+
+// RecordDAClientRetry records that a DA client request is being retried.
+// It does not start a new duration timer so can be called from within a
+// retry loop wrapped by a single RecordDAClientRequest.
+func (m *RPCMetrics) RecordDAClientRetry(method string) {
+	method = m.normalizeMethod(method)
+	m.DAClientRetriesTotal.WithLabelValues(method).Inc()
+}
+
+// RecordDAAvailabilityLag records how long after being posted data became
+// retrievable from the DA provider. Unlike the request duration this covers
+// the end-to-end delay, including any polling before the data was available.
+func (m *RPCMetrics) RecordDAAvailabilityLag(method string, d time.Duration) {
+	method = m.normalizeMethod(method)
+	m.DAClientAvailabilityLagSeconds.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// RecordRPCSubscriptionStarted records a new RPC client subscription, such
+// as eth_subscribe for new heads. RecordRPCSubscriptionEnded must be called
+// when the subscription ends.
+func (m *RPCMetrics) RecordRPCSubscriptionStarted(method string) {
+	method = m.normalizeMethod(method)
+	m.RPCActiveSubscriptions.WithLabelValues(method).Inc()
+}
+
+// RecordRPCSubscriptionEnded records the end of an RPC client subscription.
+// A non-nil err means the subscription was dropped rather than unsubscribed
+// and is recorded using the same labels as RecordRPCClientResponse.
+func (m *RPCMetrics) RecordRPCSubscriptionEnded(method string, err error) {
+	method = m.normalizeMethod(method)
+	m.RPCActiveSubscriptions.WithLabelValues(method).Dec()
+	if err != nil {
+		m.RPCSubscriptionErrorsTotal.WithLabelValues(method, ErrorLabel(err)).Inc()
+	}
+}
+
+// RPCClientErrorRatio returns the fraction of RPC client responses recorded
+// for method that were errors, or 0 if no responses have been recorded.
+// It reads the current counter values so is intended for in-process health
+// checks rather than alerting from Prometheus.
+func (m *RPCMetrics) RPCClientErrorRatio(method string) float64 {
+	method = m.normalizeMethod(method)
+	ch := make(chan prometheus.Metric)
+	go func() {
+		m.RPCClientResponsesTotal.Collect(ch)
+		close(ch)
+	}()
+	var total, failed float64
+	for metric := range ch {
+		var pb dto.Metric
+		if err := metric.Write(&pb); err != nil {
+			continue
+		}
+		var methodLabel, errorLabel string
+		for _, label := range pb.GetLabel() {
+			switch label.GetName() {
+			case "method":
+				methodLabel = label.GetValue()
+			case "error":
+				errorLabel = label.GetValue()
+			}
+		}
+		if methodLabel != method {
+			continue
+		}
+		total += pb.GetCounter().GetValue()
+		if errorLabel != "<nil>" {
+			failed += pb.GetCounter().GetValue()
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return failed / total
+}
+
+// RecordDAServerRequest is a helper method to record a request handled by the
+// DA server. It bumps the requests metric, tracks the request duration and
+// returns a function that records the response once the request completes.
+// Errors are converted into labels in the same way as for
+// RecordDAClientResponse.
+func (m *RPCMetrics) RecordDAServerRequest(method string) func(err error) {
+	method = m.normalizeMethod(method)
+	m.DAServerRequestsTotal.WithLabelValues(method).Inc()
+	timer := prometheus.NewTimer(m.DAServerRequestDurationSeconds.WithLabelValues(method))
+	return func(err error) {
+		m.DAServerResponsesTotal.WithLabelValues(method, daErrLabel(err)).Inc()
+		timer.ObserveDuration()
 	}
-	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
 }
 
 type NoopRPCMetrics struct{}
@@ -132,10 +1511,133 @@ func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
+func (n *NoopRPCMetrics) RecordRPCServerRequestWithError(method string) func(err error) {
+	return func(err error) {}
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerRequestOrigin(origin, method string) func() {
+	return func() {}
+}
+
 func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
 func (n *NoopRPCMetrics) RecordRPCClientResponse(method string, err error) {
 }
 
+func (n *NoopRPCMetrics) RecordRPCClientBatchPartial(method string, succeeded, failed int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerHOLBlocking(method string, blockedFor time.Duration) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerConnMigration() {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientFanin(method string, count int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientSpeculative(method string, used bool) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerTraceSampled(method string, sampled bool) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerAllocs(method string, bytes int64) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerShed(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientProviderVersion(provider, version string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerBufferPeak(method string, bytes int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerCompressionAlgo(algo, method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerSlowConsumerDisconnect(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerIdempotencyKey(method string, hit bool) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientFailoverLatency(method string, failedAttempts int, total time.Duration) {
+}
+
+func (n *NoopRPCMetrics) SetRPCServerAdmissionQueueDepth(method string, depth int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerCacheInvalidated(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerReplayWindowExpired(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientConnectFailure(provider string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerCoalesceWait(method string, d time.Duration) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerDependencyDepth(method string, depth int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerPaginationPage(method string, page int) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientStaleResponse(provider string, lag time.Duration) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientBatch(methods []string) func(errs []error) {
+	return func(errs []error) {}
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientResponseSize(method string, bytes int) {
+}
+
+func (n *NoopRPCMetrics) RecordDAClientRequest(method string) func(err error) {
+	return func(err error) {}
+}
+
+func (n *NoopRPCMetrics) RecordDAClientResponse(method string, err error) {
+}
+
+func (n *NoopRPCMetrics) RecordDABlobSize(method string, bytes int) {
+}
+
+func (n *NoopRPCMetrics) RecordDAClientRetry(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCServerResponse(method string, err error) {
+}
+
+func (n *NoopRPCMetrics) RecordDAServerRequest(method string) func(err error) {
+	return func(err error) {}
+}
+
+func (n *NoopRPCMetrics) RecordDAAvailabilityLag(method string, d time.Duration) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientRequestStarted(method string) time.Time {
+	return time.Now()
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientRequestFinished(method string, start time.Time, err error) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCSubscriptionStarted(method string) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCSubscriptionEnded(method string, err error) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientBatchElem(method string, elem rpc.BatchElem) {
+}
+
+func (n *NoopRPCMetrics) RecordRPCClientConnect(d time.Duration, err error) {
+}
+
 var _ RPCMetricer = (*NoopRPCMetrics)(nil)
@@ -0,0 +1,926 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestRPCMetrics() *RPCMetrics {
+	m := MakeRPCMetrics("test", With(prometheus.NewRegistry()))
+	return &m
+}
+
+// histogramOf returns the current state of a single histogram observer.
+func histogramOf(t *testing.T, observer prometheus.Observer) *dto.Histogram {
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Metric).Write(metric))
+	return metric.GetHistogram()
+}
+
+func TestRecordRPCServerRequestOrigin(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerRequestOrigin(RPCOriginInternal, "eth_chainId")()
+	m.RecordRPCServerRequestOrigin(RPCOriginExternal, "eth_chainId")()
+	m.RecordRPCServerRequestOrigin(RPCOriginExternal, "eth_chainId")()
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsByOriginTotal.WithLabelValues(RPCOriginInternal, "eth_chainId")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerRequestsByOriginTotal.WithLabelValues(RPCOriginExternal, "eth_chainId")))
+	// The plain per-method counter is still recorded regardless of origin
+	require.Equal(t, 3.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("eth_chainId")))
+
+	// Unrecognised origins are grouped so the label stays bounded
+	m.RecordRPCServerRequestOrigin("10.0.0.1", "eth_chainId")()
+	m.RecordRPCServerRequestOrigin("", "eth_chainId")()
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerRequestsByOriginTotal.WithLabelValues(RPCOriginUnknown, "eth_chainId")))
+	require.Equal(t, 3, testutil.CollectAndCount(m.RPCServerRequestsByOriginTotal))
+}
+
+func TestRecordRPCClientBatchPartial(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientBatchPartial("eth_getBlockByNumber", 7, 3)
+	m.RecordRPCClientBatchPartial("eth_getBlockByNumber", 1, 1)
+
+	require.Equal(t, 8.0, testutil.ToFloat64(m.RPCClientBatchPartialTotal.WithLabelValues("eth_getBlockByNumber", "success")))
+	require.Equal(t, 4.0, testutil.ToFloat64(m.RPCClientBatchPartialTotal.WithLabelValues("eth_getBlockByNumber", "failure")))
+}
+
+func TestRecordRPCServerHOLBlocking(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerHOLBlocking("eth_getLogs", 300*time.Millisecond)
+
+	hist := histogramOf(t, m.RPCServerHOLBlockingSeconds.WithLabelValues("eth_getLogs"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.Equal(t, 0.3, hist.GetSampleSum())
+}
+
+func TestRecordRPCServerConnMigration(t *testing.T) {
+	m := newTestRPCMetrics()
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCServerConnMigrationsTotal))
+
+	m.RecordRPCServerConnMigration()
+	m.RecordRPCServerConnMigration()
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerConnMigrationsTotal.WithLabelValues()))
+}
+
+func TestRecordRPCClientFanin(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientFanin("eth_getBlockByNumber", 4)
+	m.RecordRPCClientFanin("eth_getBlockByNumber", 16)
+
+	hist := histogramOf(t, m.RPCClientFaninHistogram.WithLabelValues("eth_getBlockByNumber"))
+	require.Equal(t, uint64(2), hist.GetSampleCount())
+	require.Equal(t, 20.0, hist.GetSampleSum())
+}
+
+func TestRecordRPCClientSpeculative(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientSpeculative("eth_call", true)
+	m.RecordRPCClientSpeculative("eth_call", false)
+	m.RecordRPCClientSpeculative("eth_call", false)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientSpeculativeUsedTotal.WithLabelValues("eth_call")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientSpeculativeWastedTotal.WithLabelValues("eth_call")))
+}
+
+func TestRecordRPCServerTraceSampled(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerTraceSampled("eth_call", true)
+	m.RecordRPCServerTraceSampled("eth_call", false)
+	m.RecordRPCServerTraceSampled("eth_call", false)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerTraceSampledTotal.WithLabelValues("eth_call", "true")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerTraceSampledTotal.WithLabelValues("eth_call", "false")))
+}
+
+func TestRecordRPCServerAllocs(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerAllocs("eth_getLogs", 2048)
+
+	hist := histogramOf(t, m.RPCServerAllocBytes.WithLabelValues("eth_getLogs"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.Equal(t, 2048.0, hist.GetSampleSum())
+}
+
+func TestTrackRPCServerAllocs(t *testing.T) {
+	m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithAllocSampleRate(1))
+
+	done := m.TrackRPCServerAllocs("eth_getLogs")
+	buf := make([]byte, 1024*1024)
+	buf[0] = 1
+	done()
+
+	hist := histogramOf(t, m.RPCServerAllocBytes.WithLabelValues("eth_getLogs"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.GreaterOrEqual(t, hist.GetSampleSum(), float64(len(buf)))
+
+	t.Run("NotSampledByDefault", func(t *testing.T) {
+		m := newTestRPCMetrics()
+		for i := 0; i < 10; i++ {
+			m.TrackRPCServerAllocs("eth_getLogs")()
+		}
+		require.Equal(t, 0, testutil.CollectAndCount(m.RPCServerAllocBytes))
+	})
+
+	t.Run("Sampled", func(t *testing.T) {
+		m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithAllocSampleRate(0.5))
+		for i := 0; i < 200; i++ {
+			m.TrackRPCServerAllocs("eth_getLogs")()
+		}
+		count := histogramOf(t, m.RPCServerAllocBytes.WithLabelValues("eth_getLogs")).GetSampleCount()
+		require.Greater(t, count, uint64(0))
+		require.Less(t, count, uint64(200))
+	})
+}
+
+func TestRecordRPCServerShed(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerShed("eth_getLogs")
+	m.RecordRPCServerShed("eth_getLogs")
+	m.RecordRPCServerShed("eth_call")
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerShedTotal.WithLabelValues("eth_getLogs")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerShedTotal.WithLabelValues("eth_call")))
+}
+
+func TestRecordRPCClientProviderVersion(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientProviderVersion("primary", "Geth/v1.13.5-stable")
+	m.RecordRPCClientProviderVersion("fallback", "Geth/v1.12.2-stable")
+	require.Equal(t, 2, testutil.CollectAndCount(m.RPCClientProviderVersion))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientProviderVersion.WithLabelValues("primary", "Geth/v1.13")))
+
+	// Upgrading a provider replaces its previous version
+	m.RecordRPCClientProviderVersion("primary", "Geth/v1.14.0-stable")
+	require.Equal(t, 2, testutil.CollectAndCount(m.RPCClientProviderVersion))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientProviderVersion.WithLabelValues("primary", "Geth/v1.14")))
+}
+
+func TestNormalizeProviderVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"Geth/v1.13.5-stable-916d6a44/linux-amd64/go1.21.5", "Geth/v1.13"},
+		{"Geth/v1.13.8-stable", "Geth/v1.13"},
+		{"erigon/2.55.1/linux-amd64/go1.21.5", "erigon/v2.55"},
+		{"Nethermind/v1.25.4+20b10b35/linux-x64/dotnet8.0.2", "Nethermind/v1.25"},
+		{"reth/v0.1.0-alpha.19", "reth/v0.1"},
+		{"op-geth/v1.101308.2-stable", "op-geth/v1.101308"},
+		{"Geth", "<unknown>"},
+		{"Geth/stable", "<unknown>"},
+		{"", "<unknown>"},
+		{"Geth/v1.13" + strings.Repeat("9", 100), "<unknown>"},
+		{strings.Repeat("a", 100) + "/v1.13.5", "<unknown>"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.version, func(t *testing.T) {
+			require.Equal(t, test.expected, normalizeProviderVersion(test.version))
+		})
+	}
+}
+
+func TestRecordRPCServerBufferPeak(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerBufferPeak("eth_sendRawTransaction", 1024)
+	m.RecordRPCServerBufferPeak("eth_sendRawTransaction", 4096)
+	m.RecordRPCServerBufferPeak("eth_sendRawTransaction", 2048)
+	m.RecordRPCServerBufferPeak("eth_call", 512)
+
+	require.Equal(t, 4096.0, testutil.ToFloat64(m.RPCServerBufferPeakBytes.WithLabelValues("eth_sendRawTransaction")))
+	require.Equal(t, 512.0, testutil.ToFloat64(m.RPCServerBufferPeakBytes.WithLabelValues("eth_call")))
+}
+
+func TestRecordRPCServerCompressionAlgo(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerCompressionAlgo("gzip", "eth_getLogs")
+	m.RecordRPCServerCompressionAlgo("gzip", "eth_getLogs")
+	m.RecordRPCServerCompressionAlgo("br", "eth_getLogs")
+	m.RecordRPCServerCompressionAlgo("zstd", "eth_getLogs")
+	m.RecordRPCServerCompressionAlgo("", "eth_getLogs")
+	m.RecordRPCServerCompressionAlgo("lz4", "eth_getLogs")
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerCompressionAlgoTotal.WithLabelValues("gzip", "eth_getLogs")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerCompressionAlgoTotal.WithLabelValues("br", "eth_getLogs")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerCompressionAlgoTotal.WithLabelValues("zstd", "eth_getLogs")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerCompressionAlgoTotal.WithLabelValues("none", "eth_getLogs")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerCompressionAlgoTotal.WithLabelValues("other", "eth_getLogs")))
+}
+
+func TestRecordRPCServerSlowConsumerDisconnect(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	// Simulate a subscriber that stops reading and is evicted once its buffer fills
+	buffer := make(chan int, 2)
+	for i := 0; i < 3; i++ {
+		select {
+		case buffer <- i:
+		default:
+			m.RecordRPCServerSlowConsumerDisconnect("eth_subscribe")
+		}
+	}
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerSlowConsumerDisconnectsTotal.WithLabelValues("eth_subscribe")))
+}
+
+func TestRecordRPCServerIdempotencyKey(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerIdempotencyKey("eth_sendRawTransaction", false)
+	m.RecordRPCServerIdempotencyKey("eth_sendRawTransaction", true)
+	m.RecordRPCServerIdempotencyKey("eth_sendRawTransaction", true)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerIdempotencyHitsTotal.WithLabelValues("eth_sendRawTransaction")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerIdempotencyMissesTotal.WithLabelValues("eth_sendRawTransaction")))
+}
+
+func TestRecordRPCClientFailoverLatency(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientFailoverLatency("eth_getBlockByNumber", 1, 1500*time.Millisecond)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientFailoverFailedAttemptsTotal.WithLabelValues("eth_getBlockByNumber")))
+	hist := histogramOf(t, m.RPCClientFailoverDurationSeconds.WithLabelValues("eth_getBlockByNumber"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.Equal(t, 1.5, hist.GetSampleSum())
+}
+
+func TestSetRPCServerAdmissionQueueDepth(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.SetRPCServerAdmissionQueueDepth("eth_call", 5)
+	require.Equal(t, 5.0, testutil.ToFloat64(m.RPCServerAdmissionQueueDepth.WithLabelValues("eth_call")))
+
+	m.SetRPCServerAdmissionQueueDepth("eth_call", 2)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerAdmissionQueueDepth.WithLabelValues("eth_call")))
+}
+
+func TestRecordRPCServerCacheInvalidated(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	// Simulate a new block invalidating every cached response that depends on the latest block
+	cached := []string{"eth_blockNumber", "eth_getBlockByNumber", "eth_getBlockByNumber"}
+	for _, method := range cached {
+		m.RecordRPCServerCacheInvalidated(method)
+	}
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerCacheInvalidationsTotal.WithLabelValues("eth_blockNumber")))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerCacheInvalidationsTotal.WithLabelValues("eth_getBlockByNumber")))
+}
+
+func TestRecordRPCServerReplayWindowExpired(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	window := 30 * time.Second
+	now := time.Now()
+	for _, sent := range []time.Time{now.Add(-10 * time.Second), now.Add(-time.Minute)} {
+		if now.Sub(sent) > window {
+			m.RecordRPCServerReplayWindowExpired("admin_sequencerActive")
+		}
+	}
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerReplayWindowExpiredTotal.WithLabelValues("admin_sequencerActive")))
+}
+
+func TestRecordRPCClientConnectFailure(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientConnectFailure("primary")
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientConnectFailuresTotal.WithLabelValues("primary")))
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCClientResponsesTotal))
+}
+
+func TestRecordRPCServerCoalesceWait(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerCoalesceWait("eth_getBalance", 20*time.Millisecond)
+
+	hist := histogramOf(t, m.RPCServerCoalesceWaitSeconds.WithLabelValues("eth_getBalance"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.Equal(t, 0.02, hist.GetSampleSum())
+}
+
+func TestRecordRPCClientResponseErrorCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		errLabel string
+		category string
+	}{
+		{"Success", nil, "<nil>", ErrorCategoryNone},
+		{"InvalidParams", &testRPCError{code: -32602}, "rpc_-32602", ErrorCategoryClient},
+		{"ServerError", &testRPCError{code: -32000}, "rpc_-32000", ErrorCategoryServer},
+		{"HTTPNotFound", rpc.HTTPError{StatusCode: http.StatusNotFound}, "http_404", ErrorCategoryClient},
+		{"HTTPUnavailable", rpc.HTTPError{StatusCode: http.StatusServiceUnavailable}, "http_503", ErrorCategoryServer},
+		{"HTTPGatewayTimeout", rpc.HTTPError{StatusCode: http.StatusGatewayTimeout}, "http_504", ErrorCategoryTimeout},
+		{"NotFound", ethereum.NotFound, "<not found>", ErrorCategoryClient},
+		{"WrappedRPCError", fmt.Errorf("call: %w", &testRPCError{code: -32601}), "rpc_-32601", ErrorCategoryClient},
+		{"WrappedHTTPError", fmt.Errorf("call: %w", rpc.HTTPError{StatusCode: http.StatusTooManyRequests}), "http_429", ErrorCategoryClient},
+		{"WrappedNotFound", fmt.Errorf("block: %w", ethereum.NotFound), "<not found>", ErrorCategoryClient},
+		{"DeadlineExceeded", context.DeadlineExceeded, "<timeout>", ErrorCategoryTimeout},
+		{"WrappedDeadlineExceeded", fmt.Errorf("request: %w", context.DeadlineExceeded), "<timeout>", ErrorCategoryTimeout},
+		{"NetTimeout", &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, "<timeout>", ErrorCategoryTimeout},
+		{"Canceled", context.Canceled, "<canceled>", ErrorCategoryClient},
+		{"WrappedCanceled", fmt.Errorf("request: %w", context.Canceled), "<canceled>", ErrorCategoryClient},
+		{"ConnectionRefused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, "<unknown>", ErrorCategoryNetwork},
+		{"Unknown", errors.New("oops"), "<unknown>", ErrorCategoryUnknown},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestRPCMetrics()
+			m.RecordRPCClientResponse("eth_call", test.err)
+			require.Equal(t, 1, testutil.CollectAndCount(m.RPCClientResponsesTotal))
+			require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_call", test.errLabel, test.category)))
+			require.Equal(t, test.errLabel, ErrorLabel(test.err))
+		})
+	}
+}
+
+type testRPCError struct {
+	code int
+	msg  string
+}
+
+func (e *testRPCError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("rpc error %d", e.code)
+}
+
+func (e *testRPCError) ErrorCode() int {
+	return e.code
+}
+
+func TestRecordRPCServerDependencyDepth(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerDependencyDepth("optimism_outputAtBlock", 2)
+	m.RecordRPCServerDependencyDepth("optimism_outputAtBlock", 3)
+
+	hist := histogramOf(t, m.RPCServerDependencyDepth.WithLabelValues("optimism_outputAtBlock"))
+	require.Equal(t, uint64(2), hist.GetSampleCount())
+	require.Equal(t, 5.0, hist.GetSampleSum())
+}
+
+func TestRecordRPCServerPaginationPage(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	for page := 1; page <= 3; page++ {
+		m.RecordRPCServerPaginationPage("eth_getLogs", page)
+	}
+
+	hist := histogramOf(t, m.RPCServerPaginationPage.WithLabelValues("eth_getLogs"))
+	require.Equal(t, uint64(3), hist.GetSampleCount())
+	require.Equal(t, 6.0, hist.GetSampleSum())
+	require.Equal(t, uint64(1), hist.GetBucket()[0].GetCumulativeCount(), "only the first page should be in the first bucket")
+}
+
+func TestRecordRPCClientStaleResponse(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientStaleResponse("replica", 10*time.Second)
+
+	hist := histogramOf(t, m.RPCClientStalenessSeconds.WithLabelValues("replica"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.Equal(t, 10.0, hist.GetSampleSum())
+}
+
+func TestRecordRPCClientBatch(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	done := m.RecordRPCClientBatch([]string{"eth_getBlockByNumber", "eth_getBlockByNumber", "eth_chainId"})
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("eth_getBlockByNumber")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("eth_chainId")))
+	require.Equal(t, 3.0, histogramOf(t, m.RPCClientBatchSize.WithLabelValues()).GetSampleSum())
+
+	// The error for the last request is missing so it is recorded as a success.
+	done([]error{nil, &testRPCError{code: -32000}})
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_getBlockByNumber", "<nil>", ErrorCategoryNone)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_getBlockByNumber", "rpc_-32000", ErrorCategoryServer)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_chainId", "<nil>", ErrorCategoryNone)))
+}
+
+func TestRecordRPCServerRequestInFlight(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	done := m.RecordRPCServerRequest("eth_chainId")
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsInFlight.WithLabelValues("eth_chainId")))
+	done()
+	require.Equal(t, 0.0, testutil.ToFloat64(m.RPCServerRequestsInFlight.WithLabelValues("eth_chainId")))
+
+	func() {
+		defer func() {
+			require.NotNil(t, recover())
+		}()
+		defer m.RecordRPCServerRequest("eth_chainId")()
+		panic("handler failed")
+	}()
+	require.Equal(t, 0.0, testutil.ToFloat64(m.RPCServerRequestsInFlight.WithLabelValues("eth_chainId")))
+}
+
+func TestRecordRPCClientResponseSize(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientResponseSize("eth_getLogs", 3000)
+
+	buckets := histogramOf(t, m.RPCClientResponseSizeBytes.WithLabelValues("eth_getLogs")).GetBucket()
+	require.Len(t, buckets, 9)
+	require.Equal(t, 256.0, buckets[0].GetUpperBound())
+	require.Equal(t, float64(16<<20), buckets[len(buckets)-1].GetUpperBound())
+	for _, bucket := range buckets {
+		expected := uint64(0)
+		if bucket.GetUpperBound() >= 4096 {
+			expected = 1
+		}
+		require.Equalf(t, expected, bucket.GetCumulativeCount(), "bucket %v", bucket.GetUpperBound())
+	}
+}
+
+func TestMakeRPCMetricsDefaultBuckets(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientRequest("eth_chainId")(nil)
+	m.RecordDAClientRequest("get_blob")(nil)
+
+	for _, hist := range []*dto.Histogram{
+		histogramOf(t, m.RPCClientRequestDurationSeconds.WithLabelValues("eth_chainId")),
+		histogramOf(t, m.DAClientRequestDurationSeconds.WithLabelValues("get_blob")),
+	} {
+		var bounds []float64
+		for _, bucket := range hist.GetBucket() {
+			bounds = append(bounds, bucket.GetUpperBound())
+		}
+		require.Equal(t, []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}, bounds)
+	}
+}
+
+func TestMakeRPCMetricsBucketOptions(t *testing.T) {
+	m := MakeRPCMetrics("test", With(prometheus.NewRegistry()),
+		WithClientDurationBuckets([]float64{1, 2}),
+		WithDABuckets([]float64{10, 30, 60}))
+
+	m.RecordRPCClientRequest("eth_chainId")(nil)
+	m.RecordDAClientRequest("get_blob")(errors.New("oops"))
+
+	require.Len(t, histogramOf(t, m.RPCClientRequestDurationSeconds.WithLabelValues("eth_chainId")).GetBucket(), 2)
+	daHist := histogramOf(t, m.DAClientRequestDurationSeconds.WithLabelValues("get_blob"))
+	require.Len(t, daHist.GetBucket(), 3)
+	require.Equal(t, 60.0, daHist.GetBucket()[2].GetUpperBound())
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DAClientRequestsTotal.WithLabelValues("get_blob")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DAClientResponsesTotal.WithLabelValues("get_blob", "<unknown>")))
+}
+
+func TestFastRPCBuckets(t *testing.T) {
+	m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithClientDurationBuckets(FastRPCBuckets))
+
+	m.RPCClientRequestDurationSeconds.WithLabelValues("eth_chainId").Observe((300 * time.Microsecond).Seconds())
+
+	buckets := histogramOf(t, m.RPCClientRequestDurationSeconds.WithLabelValues("eth_chainId")).GetBucket()
+	require.Len(t, buckets, len(FastRPCBuckets))
+	require.Equal(t, 0.0001, buckets[0].GetUpperBound())
+	require.Zero(t, buckets[0].GetCumulativeCount(), "300µs should be above the lowest bucket")
+	require.Zero(t, buckets[1].GetCumulativeCount())
+	require.Equal(t, uint64(1), buckets[2].GetCumulativeCount())
+}
+
+func TestRecordDABlobSize(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordDABlobSize("put_blob", 128*1024)
+
+	require.Equal(t, 1, testutil.CollectAndCount(m.DAClientBlobSizeBytes))
+	hist := histogramOf(t, m.DAClientBlobSizeBytes.WithLabelValues("put_blob"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	for _, bucket := range hist.GetBucket() {
+		expected := uint64(0)
+		if bucket.GetUpperBound() >= 128*1024 {
+			expected = 1
+		}
+		require.Equalf(t, expected, bucket.GetCumulativeCount(), "bucket %v", bucket.GetUpperBound())
+	}
+}
+
+func TestRecordDAClientRetry(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	for i := 1; i <= 3; i++ {
+		m.RecordDAClientRetry("get_blob")
+		require.Equal(t, float64(i), testutil.ToFloat64(m.DAClientRetriesTotal.WithLabelValues("get_blob")))
+	}
+	require.Equal(t, 0, testutil.CollectAndCount(m.DAClientRequestDurationSeconds))
+}
+
+func TestRecordDAAvailabilityLag(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordDAAvailabilityLag("get_blob", 3*time.Second)
+	m.RecordDAAvailabilityLag("get_blob", 90*time.Second)
+
+	hist := histogramOf(t, m.DAClientAvailabilityLagSeconds.WithLabelValues("get_blob"))
+	require.Equal(t, uint64(2), hist.GetSampleCount())
+	require.Equal(t, 93.0, hist.GetSampleSum())
+	require.Equal(t, 600.0, hist.GetBucket()[len(hist.GetBucket())-1].GetUpperBound())
+}
+
+func TestServerErrorMessageClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"NonceTooLow", &testRPCError{code: -32000, msg: "nonce too low: address 0x01, tx: 1 state: 2"}, "rpc_-32000_nonce_too_low"},
+		{"InsufficientFunds", &testRPCError{code: -32000, msg: "insufficient funds for gas * price + value"}, "rpc_-32000_insufficient_funds"},
+		{"ReplacementUnderpriced", &testRPCError{code: -32000, msg: "replacement transaction underpriced"}, "rpc_-32000_replacement_transaction_underpriced"},
+		{"Underpriced", &testRPCError{code: -32000, msg: "transaction underpriced: tip needed 1, tip permitted 0"}, "rpc_-32000_transaction_underpriced"},
+		{"Wrapped", fmt.Errorf("send: %w", &testRPCError{code: -32000, msg: "already known"}), "rpc_-32000_already_known"},
+		{"UnknownMessage", &testRPCError{code: -32000, msg: "something unexpected"}, "rpc_-32000"},
+		{"OtherCode", &testRPCError{code: -32602, msg: "nonce too low"}, "rpc_-32602"},
+		{"NotRPCError", errors.New("nonce too low"), "<unknown>"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithServerErrorMessageClassification())
+			m.RecordRPCClientResponse("eth_sendRawTransaction", test.err)
+			_, category := classifyError(test.err)
+			require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_sendRawTransaction", test.expected, category)))
+		})
+	}
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		m := newTestRPCMetrics()
+		m.RecordRPCClientResponse("eth_sendRawTransaction", &testRPCError{code: -32000, msg: "nonce too low"})
+		require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_sendRawTransaction", "rpc_-32000", ErrorCategoryServer)))
+	})
+}
+
+func TestMethodAllowlist(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := newTestRPCMetrics()
+		m.RecordRPCServerRequest("garbage_method")()
+		require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("garbage_method")))
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithMethodAllowlist([]string{"eth_chainId"}))
+		m.RecordRPCServerRequest("eth_chainId")()
+		m.RecordRPCServerRequest("garbage_method")()
+		m.RecordRPCServerRequest("other_garbage")()
+		m.RecordRPCClientRequest("garbage_method")(nil)
+		m.RecordRPCServerBufferPeak("garbage_method", 10)
+
+		require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("eth_chainId")))
+		require.Equal(t, 2.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("other")))
+		require.Equal(t, 2, testutil.CollectAndCount(m.RPCServerRequestsTotal))
+		require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("other")))
+		require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("other", "<nil>", ErrorCategoryNone)))
+		require.Equal(t, 10.0, testutil.ToFloat64(m.RPCServerBufferPeakBytes.WithLabelValues("other")))
+	})
+}
+
+func TestRecordRPCServerResponse(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCServerResponse("eth_call", &testRPCError{code: -32000})
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("eth_call", "rpc_-32000")))
+
+	// The error can also be passed to the function returned when recording the request
+	m.RecordRPCServerRequestWithError("eth_call")(&testRPCError{code: -32602})
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("eth_call", "rpc_-32602")))
+	m.RecordRPCServerRequestWithError("eth_call")(nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerResponsesTotal.WithLabelValues("eth_call", "<nil>")))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.RPCServerRequestsInFlight.WithLabelValues("eth_call")))
+
+	// Responses are not recorded by RecordRPCServerRequest
+	m.RecordRPCServerRequest("eth_call")()
+	require.Equal(t, 3, int(testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("eth_call"))))
+	require.Equal(t, 3, testutil.CollectAndCount(m.RPCServerResponsesTotal))
+}
+
+func TestReset(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := MakeRPCMetrics("test", With(registry), WithDurationSummaries([]string{"eth_chainId"}))
+
+	m.RecordRPCServerRequestOrigin(RPCOriginExternal, "eth_chainId")()
+	m.RecordRPCServerRequestWithError("eth_chainId")(errors.New("oops"))
+	m.RecordRPCClientRequest("eth_chainId")(errors.New("oops"))
+	m.RecordRPCClientBatchPartial("eth_chainId", 1, 1)
+	m.RecordRPCServerHOLBlocking("eth_chainId", time.Millisecond)
+	m.RecordRPCServerConnMigration()
+	m.RecordRPCClientFanin("eth_chainId", 2)
+	m.RecordRPCClientSpeculative("eth_chainId", true)
+	m.RecordRPCClientSpeculative("eth_chainId", false)
+	m.RecordRPCServerTraceSampled("eth_chainId", true)
+	m.RecordRPCServerAllocs("eth_chainId", 1024)
+	m.RecordRPCServerShed("eth_chainId")
+	m.RecordRPCClientProviderVersion("primary", "Geth/v1.13.5-stable/linux")
+	m.RecordRPCServerBufferPeak("eth_chainId", 4096)
+	m.RecordRPCServerCompressionAlgo("gzip", "eth_chainId")
+	m.RecordRPCServerSlowConsumerDisconnect("eth_chainId")
+	m.RecordRPCServerIdempotencyKey("eth_chainId", true)
+	m.RecordRPCServerIdempotencyKey("eth_chainId", false)
+	m.RecordRPCClientFailoverLatency("eth_chainId", 1, time.Second)
+	m.SetRPCServerAdmissionQueueDepth("eth_chainId", 3)
+	m.RecordRPCServerCacheInvalidated("eth_chainId")
+	m.RecordRPCServerReplayWindowExpired("eth_chainId")
+	m.RecordRPCClientConnectFailure("primary")
+	m.RecordRPCClientConnect(time.Millisecond, errors.New("refused"))
+	m.RecordRPCServerCoalesceWait("eth_chainId", time.Millisecond)
+	m.RecordRPCServerDependencyDepth("eth_chainId", 2)
+	m.RecordRPCServerPaginationPage("eth_chainId", 1)
+	m.RecordRPCClientStaleResponse("primary", time.Second)
+	m.RecordRPCClientBatch([]string{"eth_chainId"})([]error{nil})
+	m.RecordRPCClientResponseSize("eth_chainId", 128)
+	m.RecordDAClientRequest("get_blob")(nil)
+	m.RecordDABlobSize("put_blob", 1024)
+	m.RecordDAClientRetry("get_blob")
+	m.RecordDAAvailabilityLag("get_blob", time.Second)
+	m.RecordDAServerRequest("get_blob")(nil)
+	m.RecordRPCSubscriptionStarted("eth_subscribe")
+	m.RecordRPCSubscriptionEnded("eth_subscribe", errors.New("dropped"))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.NotEmpty(t, families)
+
+	m.Reset()
+	families, err = registry.Gather()
+	require.NoError(t, err)
+	require.Empty(t, families)
+
+	// The buffer peak tracker is reset too, so a smaller value is a new peak
+	m.RecordRPCServerBufferPeak("eth_chainId", 1024)
+	require.Equal(t, 1024.0, testutil.ToFloat64(m.RPCServerBufferPeakBytes))
+
+	// Collectors are still registered and continue to record values
+	m.RecordRPCServerRequest("eth_chainId")()
+	count, err := testutil.GatherAndCount(registry, "test_rpc_server_requests_total")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestRPCClientErrorRatio(t *testing.T) {
+	m := newTestRPCMetrics()
+	require.Zero(t, m.RPCClientErrorRatio("eth_call"))
+
+	m.RecordRPCClientResponse("eth_call", nil)
+	m.RecordRPCClientResponse("eth_call", nil)
+	m.RecordRPCClientResponse("eth_call", nil)
+	m.RecordRPCClientResponse("eth_call", &testRPCError{code: -32000})
+	m.RecordRPCClientResponse("eth_chainId", errors.New("oops"))
+
+	require.Equal(t, 0.25, m.RPCClientErrorRatio("eth_call"))
+	require.Equal(t, 1.0, m.RPCClientErrorRatio("eth_chainId"))
+	require.Zero(t, m.RPCClientErrorRatio("eth_getLogs"))
+}
+
+func TestRecordDAServerRequest(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordDAServerRequest("get_blob")(status.Error(codes.NotFound, "blob not found"))
+	m.RecordDAServerRequest("get_blob")(nil)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.DAServerRequestsTotal.WithLabelValues("get_blob")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DAServerResponsesTotal.WithLabelValues("get_blob", "grpc_NotFound")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DAServerResponsesTotal.WithLabelValues("get_blob", "<nil>")))
+	require.Equal(t, uint64(2), histogramOf(t, m.DAServerRequestDurationSeconds.WithLabelValues("get_blob")).GetSampleCount())
+
+	m.RecordDAClientResponse("get_blob", fmt.Errorf("get: %w", status.Error(codes.NotFound, "blob not found")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DAClientResponsesTotal.WithLabelValues("get_blob", "grpc_NotFound")))
+}
+
+func TestBackendLabel(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	a := MakeRPCMetrics("test", With(registry), WithBackendLabel("a"))
+	b := MakeRPCMetrics("test", With(registry), WithBackendLabel("b"))
+
+	a.RecordRPCClientRequest("eth_chainId")(nil)
+	b.RecordRPCClientRequest("eth_chainId")(nil)
+	b.RecordRPCClientRequest("eth_chainId")(nil)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(a.RPCClientRequestsTotal.WithLabelValues("eth_chainId")))
+	require.Equal(t, 2.0, testutil.ToFloat64(b.RPCClientRequestsTotal.WithLabelValues("eth_chainId")))
+	count, err := testutil.GatherAndCount(registry, "test_rpc_client_requests_total")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "test_rpc_client_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			require.Len(t, metric.GetLabel(), 2)
+			require.Equal(t, "backend", metric.GetLabel()[0].GetName())
+		}
+	}
+
+	// Without the option there is no backend label.
+	registry = prometheus.NewRegistry()
+	m := MakeRPCMetrics("test", With(registry))
+	m.RecordRPCClientRequest("eth_chainId")(nil)
+	families, err = registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "test_rpc_client_requests_total" {
+			continue
+		}
+		require.Len(t, family.GetMetric()[0].GetLabel(), 1)
+	}
+}
+
+func TestRecordRPCClientRequestStartedFinished(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	start := m.RecordRPCClientRequestStarted("eth_subscribe")
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("eth_subscribe")))
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCClientResponsesTotal))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.RecordRPCClientRequestFinished("eth_subscribe", start.Add(-2*time.Second), &testRPCError{code: -32000})
+	}()
+	<-done
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_subscribe", "rpc_-32000", ErrorCategoryServer)))
+	hist := histogramOf(t, m.RPCClientRequestDurationSeconds.WithLabelValues("eth_subscribe"))
+	require.Equal(t, uint64(1), hist.GetSampleCount())
+	require.GreaterOrEqual(t, hist.GetSampleSum(), 2.0)
+
+	// The closure API records the same metrics
+	m.RecordRPCClientRequest("eth_subscribe")(nil)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientRequestsTotal.WithLabelValues("eth_subscribe")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_subscribe", "<nil>", ErrorCategoryNone)))
+	require.Equal(t, uint64(2), histogramOf(t, m.RPCClientRequestDurationSeconds.WithLabelValues("eth_subscribe")).GetSampleCount())
+}
+
+func TestRecordRPCSubscription(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCSubscriptionStarted("eth_subscribe")
+	m.RecordRPCSubscriptionStarted("eth_subscribe")
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCActiveSubscriptions.WithLabelValues("eth_subscribe")))
+
+	m.RecordRPCSubscriptionEnded("eth_subscribe", nil)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCActiveSubscriptions.WithLabelValues("eth_subscribe")))
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCSubscriptionErrorsTotal))
+
+	m.RecordRPCSubscriptionEnded("eth_subscribe", errors.New("connection reset"))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.RPCActiveSubscriptions.WithLabelValues("eth_subscribe")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCSubscriptionErrorsTotal.WithLabelValues("eth_subscribe", "<unknown>")))
+}
+
+type testHTTPStatusError struct {
+	code int
+}
+
+func (e *testHTTPStatusError) Error() string {
+	return fmt.Sprintf("http status %d", e.code)
+}
+
+func (e *testHTTPStatusError) StatusCode() int {
+	return e.code
+}
+
+func TestRecordDAClientResponseHTTPError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"HTTPTooManyRequests", rpc.HTTPError{StatusCode: http.StatusTooManyRequests}, "http_429"},
+		{"WrappedHTTPError", fmt.Errorf("get blob: %w", rpc.HTTPError{StatusCode: http.StatusTooManyRequests}), "http_429"},
+		{"StatusCodeError", &testHTTPStatusError{code: http.StatusTooManyRequests}, "http_429"},
+		{"GRPCUnavailable", status.Error(codes.Unavailable, "unavailable"), "grpc_Unavailable"},
+		{"Unknown", errors.New("oops"), "<unknown>"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestRPCMetrics()
+			m.RecordDAClientResponse("get_blob", test.err)
+			require.Equal(t, 1.0, testutil.ToFloat64(m.DAClientResponsesTotal.WithLabelValues("get_blob", test.expected)))
+		})
+	}
+}
+
+func TestConstLabels(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := MakeRPCMetrics("test", With(registry),
+		WithConstLabels(prometheus.Labels{"region": "eu", "chain_id": "10"}),
+		WithBackendLabel("a"))
+
+	m.RecordRPCServerRequest("eth_chainId")()
+	m.RecordRPCClientRequest("eth_chainId")(nil)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.NotEmpty(t, families)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			require.Equalf(t, "eu", labels["region"], "Missing region label on %v", family.GetName())
+			require.Equalf(t, "10", labels["chain_id"], "Missing chain_id label on %v", family.GetName())
+			require.Equalf(t, "a", labels["backend"], "Missing backend label on %v", family.GetName())
+		}
+	}
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCServerRequestsTotal.WithLabelValues("eth_chainId")))
+}
+
+func TestDurationSummaries(t *testing.T) {
+	m := MakeRPCMetrics("test", With(prometheus.NewRegistry()), WithDurationSummaries([]string{"eth_call"}))
+
+	start := time.Now()
+	for i := 1; i <= 100; i++ {
+		m.RecordRPCClientRequestFinished("eth_call", start.Add(-time.Duration(i)*time.Millisecond), nil)
+	}
+	m.RecordRPCClientRequestFinished("eth_chainId", start, nil)
+
+	var pb dto.Metric
+	require.NoError(t, m.RPCClientRequestDurationSummary.WithLabelValues("eth_call").(prometheus.Metric).Write(&pb))
+	summary := pb.GetSummary()
+	require.Equal(t, uint64(100), summary.GetSampleCount())
+	quantiles := make(map[float64]float64)
+	for _, q := range summary.GetQuantile() {
+		quantiles[q.GetQuantile()] = q.GetValue()
+	}
+	require.InDelta(t, 0.050, quantiles[0.5], 0.01)
+	require.InDelta(t, 0.099, quantiles[0.99], 0.01)
+	// Only the selected methods are recorded in the summary
+	require.Equal(t, 1, testutil.CollectAndCount(m.RPCClientRequestDurationSummary))
+
+	// Summaries are disabled by default
+	require.Nil(t, newTestRPCMetrics().RPCClientRequestDurationSummary)
+}
+
+func TestRecordRPCClientBatchElem(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	elems := []rpc.BatchElem{
+		{Method: "eth_getBlockByNumber"},
+		{Method: "eth_getBlockByNumber", Error: &testRPCError{code: -32000}},
+		{Method: "eth_getBlockByNumber", Error: ethereum.NotFound},
+		{Method: "eth_getBlockByNumber"},
+	}
+	for _, elem := range elems {
+		m.RecordRPCClientBatchElem(elem.Method, elem)
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_getBlockByNumber", "<nil>", ErrorCategoryNone)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_getBlockByNumber", "rpc_-32000", ErrorCategoryServer)))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientResponsesTotal.WithLabelValues("eth_getBlockByNumber", "<not found>", ErrorCategoryClient)))
+}
+
+func TestRecordRPCClientConnect(t *testing.T) {
+	m := newTestRPCMetrics()
+
+	m.RecordRPCClientConnect(20*time.Millisecond, nil)
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCClientConnectErrorsTotal))
+
+	m.RecordRPCClientConnect(2*time.Second, context.DeadlineExceeded)
+	m.RecordRPCClientConnect(5*time.Millisecond, &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+
+	hist := histogramOf(t, m.RPCClientConnectDurationSeconds.WithLabelValues())
+	require.Equal(t, uint64(3), hist.GetSampleCount())
+	require.InDelta(t, 2.025, hist.GetSampleSum(), 0.0001)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientConnectErrorsTotal.WithLabelValues("<timeout>")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.RPCClientConnectErrorsTotal.WithLabelValues("<unknown>")))
+	// Connection failures are not counted as responses
+	require.Equal(t, 0, testutil.CollectAndCount(m.RPCClientResponsesTotal))
+}
@@ -18,11 +18,11 @@ func ToGethAdminAPI(api *CommonAdminAPI) rpc.API {
 }
 
 type CommonAdminAPI struct {
-	M   metrics.RPCMetricer
+	M   metrics.RPCRequestMetricer
 	log log.Logger
 }
 
-func NewCommonAdminAPI(m metrics.RPCMetricer, log log.Logger) *CommonAdminAPI {
+func NewCommonAdminAPI(m metrics.RPCRequestMetricer, log log.Logger) *CommonAdminAPI {
 	return &CommonAdminAPI{
 		M:   m,
 		log: log,
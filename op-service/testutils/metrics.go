@@ -2,6 +2,7 @@ package testutils
 
 import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 )
 
 // TestDerivationMetrics implements the metrics used in the derivation pipeline as no-op operations.
@@ -56,14 +57,7 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
-type TestRPCMetrics struct{}
-
-func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
-	return func() {}
-}
-
-func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
-	return func(err error) {}
+// TestRPCMetrics implements the RPC metrics as no-op operations.
+type TestRPCMetrics struct {
+	metrics.NoopRPCMetrics
 }
-
-func (n *TestRPCMetrics) RecordRPCClientResponse(method string, err error) {}
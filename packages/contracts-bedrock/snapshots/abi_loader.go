@@ -22,6 +22,9 @@ var mips []byte
 //go:embed abi/DelayedWETH.json
 var delayedWETH []byte
 
+//go:embed abi/AnchorStateRegistry.json
+var anchorStateRegistry []byte
+
 func LoadDisputeGameFactoryABI() *abi.ABI {
 	return loadABI(disputeGameFactory)
 }
@@ -38,6 +41,10 @@ func LoadDelayedWETHABI() *abi.ABI {
 	return loadABI(delayedWETH)
 }
 
+func LoadAnchorStateRegistryABI() *abi.ABI {
+	return loadABI(anchorStateRegistry)
+}
+
 func loadABI(json []byte) *abi.ABI {
 	if parsed, err := abi.JSON(bytes.NewReader(json)); err != nil {
 		panic(err)